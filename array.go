@@ -0,0 +1,148 @@
+package makroud
+
+import (
+	"database/sql"
+	"reflect"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// isArrayType returns if given type is a slice of scalar values, which is stored in a PostgreSQL array column.
+func isArrayType(rtype reflect.Type) bool {
+	if rtype.Kind() != reflect.Slice || reflect.PtrTo(rtype).Implements(scannerType) {
+		return false
+	}
+
+	elem := rtype.Elem()
+	if elem.Kind() == reflect.Uint8 || elem.Kind() == reflect.Ptr {
+		return false
+	}
+
+	if reflect.PtrTo(elem).Implements(scannerType) {
+		return true
+	}
+
+	switch elem.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Slice:
+		// Only for bytea[] column.
+		return elem.Elem().Kind() == reflect.Uint8
+	default:
+		return false
+	}
+}
+
+// arrayScanner is a sql.Scanner that scans a PostgreSQL array column into a slice of any scalar type.
+type arrayScanner struct {
+	dest interface{}
+}
+
+// Scan implements sql.Scanner interface.
+func (scanner arrayScanner) Scan(src interface{}) error {
+	value := reflect.ValueOf(scanner.dest).Elem()
+
+	if value.Kind() == reflect.Ptr {
+		if src == nil {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+
+	rtype := value.Type()
+	if rtype.Kind() != reflect.Slice {
+		return pq.Array(value.Addr().Interface()).Scan(src)
+	}
+
+	if src == nil {
+		value.Set(reflect.Zero(rtype))
+		return nil
+	}
+
+	// Use an intermediate slice supported by lib/pq, which is then converted to destination element type.
+	var proxy interface{}
+	switch rtype.Elem().Kind() {
+	case reflect.Bool:
+		proxy = &[]bool{}
+	case reflect.String:
+		proxy = &[]string{}
+	case reflect.Float32, reflect.Float64:
+		proxy = &[]float64{}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		proxy = &[]int64{}
+	default:
+		return pq.Array(value.Addr().Interface()).Scan(src)
+	}
+
+	err := pq.Array(proxy).Scan(src)
+	if err != nil {
+		return err
+	}
+
+	items := reflect.ValueOf(proxy).Elem()
+	slice := reflect.MakeSlice(rtype, items.Len(), items.Len())
+	for i := 0; i < items.Len(); i++ {
+		item, err := convertArrayItem(items.Index(i), rtype.Elem())
+		if err != nil {
+			return err
+		}
+		slice.Index(i).Set(item)
+	}
+	value.Set(slice)
+
+	return nil
+}
+
+// convertArrayItem converts given item from the intermediate slice to given element type.
+// It returns an error if the item doesn't fit in this type, instead of silently truncating it.
+func convertArrayItem(item reflect.Value, rtype reflect.Type) (reflect.Value, error) {
+	overflow := false
+	zero := reflect.Zero(rtype)
+
+	switch rtype.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		overflow = zero.OverflowInt(item.Int())
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		overflow = item.Int() < 0 || zero.OverflowUint(uint64(item.Int()))
+	case reflect.Float32, reflect.Float64:
+		overflow = zero.OverflowFloat(item.Float())
+	}
+
+	if overflow {
+		return reflect.Value{}, errors.Errorf("cannot scan %v into %s: value out of range",
+			item.Interface(), rtype.String())
+	}
+
+	return item.Convert(rtype), nil
+}
+
+// toArrayValue wraps given slice so it can be stored in a PostgreSQL array column.
+// A nil slice is stored as NULL.
+func toArrayValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	reflected := reflect.Indirect(reflect.ValueOf(value))
+	switch reflected.Kind() {
+	case reflect.Slice:
+		if reflected.IsNil() {
+			return nil, nil
+		}
+	case reflect.Array:
+	default:
+		return nil, errors.Errorf("cannot use %T as array", value)
+	}
+
+	return pq.Array(reflected.Interface()), nil
+}
@@ -3,6 +3,8 @@ package makroud
 import (
 	"reflect"
 	"sync"
+
+	"github.com/ulule/makroud/reflectx"
 )
 
 // DriverCache is driver cache used to store Schema and Schemaless information.
@@ -19,10 +21,25 @@ func NewDriverCache() *DriverCache {
 	}
 }
 
+// schemaKey is the cache key of a Schema.
+// Two models using the same table, such as a full model and a lightweight read model, have their own Schema.
+type schemaKey struct {
+	rtype     reflect.Type
+	tableName string
+}
+
+// getSchemaKey returns the cache key of given model.
+func getSchemaKey(model Model) schemaKey {
+	return schemaKey{
+		rtype:     reflectx.GetIndirectType(model),
+		tableName: model.TableName(),
+	}
+}
+
 // GetSchema returns the schema associated to given model from cache.
 // If it does not exists, it returns nil.
 func (c *DriverCache) GetSchema(model Model) *Schema {
-	schema, ok := c.schemas.Load(model.TableName())
+	schema, ok := c.schemas.Load(getSchemaKey(model))
 	if !ok {
 		return nil
 	}
@@ -35,7 +52,7 @@ func (c *DriverCache) SetSchema(schema *Schema) {
 	if schema == nil {
 		panic("makroud: schema shouldn't be nil")
 	}
-	c.schemas.Store(schema.TableName(), schema)
+	c.schemas.Store(getSchemaKey(schema.Model()), schema)
 }
 
 // GetSchemaless returns the schemaless associated to type from cache.
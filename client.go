@@ -5,15 +5,21 @@ import (
 	"database/sql"
 	"io"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
+
+	"github.com/ulule/makroud/hooks"
 )
 
 // ClientDriver defines the driver name used in makroud.
 const ClientDriver = "postgres"
 
+// TracerSystem defines the database system given to tracing spans.
+const TracerSystem = "postgresql"
+
 // Client is a wrapper that can interact with the database, it's an implementation of Driver.
 type Client struct {
 	node  Node
@@ -21,6 +27,16 @@ type Client struct {
 	log   Logger
 	obs   Observer
 	rnd   io.Reader
+	tnt   string
+	rdc   bool
+	slw   time.Duration
+	exp   *sync.Map
+	mtr   MetricsRegistry
+	cdc   map[string]Codec
+	gen   map[string]PrimaryKeyGenerator
+	clk   Clock
+	lnt   bool
+	cbs   *txCallbacks
 }
 
 // New returns a new Client instance.
@@ -49,6 +65,17 @@ func NewWithOptions(options *ClientOptions) (*Client, error) {
 	client := &Client{
 		node: node,
 		rnd:  entropy,
+		tnt:  options.TenantColumn,
+		rdc:  options.RedactArgs,
+		slw:  options.SlowQueryThreshold,
+		cdc:  options.Codecs,
+		gen:  getPrimaryKeyGenerators(options),
+		clk:  options.Clock,
+		lnt:  options.LenientScan,
+	}
+
+	if options.ExplainSlowQueries {
+		client.exp = &sync.Map{}
 	}
 
 	if options.WithCache {
@@ -63,16 +90,32 @@ func NewWithOptions(options *ClientOptions) (*Client, error) {
 		client.obs = options.Observer
 	}
 
+	if options.Metrics != nil {
+		client.mtr = options.Metrics
+		registerPoolMetrics(client)
+	}
+
 	return client, nil
 }
 
 // Exec executes a statement using given arguments.
 func (c *Client) Exec(ctx context.Context, query string, args ...interface{}) error {
-	_, err := c.node.ExecContext(ctx, query, args...)
+	tracker := newQueryTracker(ctx, c, query, args)
+
+	result, err := c.node.ExecContext(ctx, query, args...)
 	if err != nil {
+		tracker.done(err)
 		return errors.Wrap(err, "makroud: cannot execute query")
 	}
 
+	if tracker != nil {
+		affected, err := result.RowsAffected()
+		if err == nil {
+			tracker.affected(affected)
+		}
+		tracker.done(nil)
+	}
+
 	return nil
 }
 
@@ -87,20 +130,26 @@ func (c *Client) MustExec(ctx context.Context, query string, args ...interface{}
 
 // Query executes a statement that returns rows using given arguments.
 func (c *Client) Query(ctx context.Context, query string, args ...interface{}) (Rows, error) {
+	tracker := newQueryTracker(ctx, c, query, args)
+
 	rows, err := c.node.QueryContext(ctx, query, args...)
 	if err != nil {
+		tracker.done(err)
 		return nil, errors.Wrap(err, "makroud: cannot execute query")
 	}
-	return wrapRows(rows), nil
+	return wrapRowsWithTracker(rows, tracker), nil
 }
 
 // QueryRow executes a statement returning a single row.
 func (c *Client) QueryRow(ctx context.Context, query string, args ...interface{}) (Row, error) {
+	tracker := newQueryTracker(ctx, c, query, args)
+
 	rows, err := c.node.QueryContext(ctx, query, args...)
 	if err != nil {
+		tracker.done(err)
 		return nil, errors.Wrap(err, "makroud: cannot execute query")
 	}
-	return wrapRow(rows), nil
+	return wrapRowWithTracker(rows, tracker), nil
 }
 
 // MustQuery executes a statement that returns rows using given arguments.
@@ -142,12 +191,24 @@ func (c *Client) Begin(ctx context.Context, opts ...*TxOptions) (Driver, error)
 	if err != nil {
 		return nil, errors.Wrap(err, "makroud: cannot create a transaction")
 	}
-	return wrapClient(c, node), nil
+
+	tx := wrapClient(c, node)
+	tx.cbs = newTxCallbacks(c.cbs)
+
+	return tx, nil
 }
 
 // Rollback rollbacks the associated transaction.
 func (c *Client) Rollback() error {
+	reused := isReusedTransaction(c.node)
 	err := c.node.Rollback()
+	if c.cbs != nil && reused {
+		// Without savepoint, the queries of a nested transaction are not rolled back here but with the
+		// outermost transaction: so its callbacks are kept for the parent transaction.
+		c.cbs.commit()
+	} else if c.cbs != nil {
+		c.cbs.rollback()
+	}
 	if err != nil {
 		return errors.Wrap(err, "makroud: cannot rollback transaction")
 	}
@@ -158,8 +219,14 @@ func (c *Client) Rollback() error {
 func (c *Client) Commit() error {
 	err := c.node.Commit()
 	if err != nil {
+		if c.cbs != nil && err != ErrCommitNotInTransaction {
+			c.cbs.rollback()
+		}
 		return errors.Wrap(err, "makroud: cannot commit transaction")
 	}
+	if c.cbs != nil {
+		c.cbs.commit()
+	}
 	return nil
 }
 
@@ -263,13 +330,80 @@ func (c *Client) Entropy() io.Reader {
 	return c.rnd
 }
 
+// Stats returns the connections pool statistics.
+func (c *Client) Stats() sql.DBStats {
+	return c.node.Stats()
+}
+
+// codec returns the field codec registered with given name.
+func (c *Client) codec(name string) (Codec, bool) {
+	codec, ok := c.cdc[name]
+	return codec, ok
+}
+
+// generator returns the primary key generator registered with given name.
+func (c *Client) generator(name string) (PrimaryKeyGenerator, bool) {
+	generator, ok := c.gen[name]
+	return generator, ok
+}
+
+// lenientScan returns if columns without destination in a model are discarded, instead of returning an error.
+func (c *Client) lenientScan() bool {
+	return c.lnt
+}
+
+// clock returns the client clock, if any.
+func (c *Client) clock() (Clock, bool) {
+	return c.clk, c.clk != nil
+}
+
+// client returns this Client: it's backing itself.
+func (c *Client) client() *Client {
+	return c
+}
+
+// tenantColumn returns the column used to isolate rows by tenant, if any.
+func (c *Client) tenantColumn() string {
+	return c.tnt
+}
+
+// metrics returns the metrics registry, if any.
+func (c *Client) metrics() MetricsRegistry {
+	return c.mtr
+}
+
+// A clientDriver is a Driver backed by a Client, such as a Client, a Router or a type embedding a Client.
+// It gives access to the Client configuration without exposing it on Driver interface.
+type clientDriver interface {
+	client() *Client
+}
+
+// getClient returns the Client backing given driver, or nil if it's not backed by a Client.
+func getClient(driver Driver) *Client {
+	backed, ok := driver.(clientDriver)
+	if !ok {
+		return nil
+	}
+	return backed.client()
+}
+
 // wrapClient creates a new Client using given database connection.
-func wrapClient(client *Client, connection Node) Driver {
+func wrapClient(client *Client, connection Node) *Client {
 	return &Client{
 		node:  connection,
 		cache: client.cache,
 		log:   client.log,
+		obs:   client.obs,
 		rnd:   client.rnd,
+		tnt:   client.tnt,
+		rdc:   client.rdc,
+		slw:   client.slw,
+		exp:   client.exp,
+		mtr:   client.mtr,
+		cdc:   client.cdc,
+		gen:   client.gen,
+		clk:   client.clk,
+		lnt:   client.lnt,
 	}
 }
 
@@ -281,7 +415,7 @@ func getNodeForClient(options *ClientOptions) (Node, error) {
 
 	_ = pq.Driver{}
 
-	node, err := Connect(ClientDriver, options.String())
+	node, err := connectForClient(options)
 	if err != nil {
 		return nil, errors.Wrapf(err, "makroud: cannot connect to %s server", ClientDriver)
 	}
@@ -293,13 +427,27 @@ func getNodeForClient(options *ClientOptions) (Node, error) {
 	return node, nil
 }
 
+// connectForClient connects to the database, using a traced connector if a tracer is required.
+func connectForClient(options *ClientOptions) (Node, error) {
+	if options.Tracer == nil {
+		return Connect(ClientDriver, options.String())
+	}
+
+	connector, err := pq.NewConnector(options.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return ConnectWithConnector(ClientDriver, hooks.Trace(connector, options.Tracer, TracerSystem))
+}
+
 // getEntropyForClient returns the required entropy source for client creation.
 func getEntropyForClient(options *ClientOptions) io.Reader {
 	if options.Entropy != nil {
 		return options.Entropy
 	}
 
-	return rand.New(rand.NewSource(time.Now().UnixNano()))
+	return NewMonotonicEntropy(rand.New(rand.NewSource(time.Now().UnixNano())))
 }
 
 // A stmtWrapper wraps a statement from sql.
@@ -353,13 +501,20 @@ func (w *stmtWrapper) QueryRows(ctx context.Context, args ...interface{}) (Rows,
 // A rowWrapper is a reimplementation of sql.Row in order to gain access to the underlying
 // Columns() function.
 type rowWrapper struct {
-	rows *sql.Rows
+	rows    *sql.Rows
+	tracker *queryTracker
 }
 
 // wrapRow creates a new Row using given rows from sql.
 func wrapRow(rows *sql.Rows) Row {
+	return wrapRowWithTracker(rows, nil)
+}
+
+// wrapRowWithTracker creates a new Row using given rows from sql, reporting its outcome to given tracker.
+func wrapRowWithTracker(rows *sql.Rows, tracker *queryTracker) Row {
 	return &rowWrapper{
-		rows: rows,
+		rows:    rows,
+		tracker: tracker,
 	}
 }
 
@@ -386,6 +541,14 @@ func (r *rowWrapper) Columns() ([]string, error) {
 // The number of values in dest must be the same as the number of columns in Rows.
 func (r *rowWrapper) Scan(dest ...interface{}) error {
 	err := r.scan(dest...)
+	if err == nil {
+		r.tracker.row()
+	}
+	if err == sql.ErrNoRows {
+		r.tracker.done(nil)
+	} else {
+		r.tracker.done(err)
+	}
 	if err != nil {
 		return errors.Wrap(err, "makroud: cannot scan given values")
 	}
@@ -424,13 +587,20 @@ func (r *rowWrapper) scan(dest ...interface{}) error {
 
 // A rowsWrapper wraps a rows from sql.
 type rowsWrapper struct {
-	rows *sql.Rows
+	rows    *sql.Rows
+	tracker *queryTracker
 }
 
 // wrapRow creates a new Rows using given rows from sql.
 func wrapRows(rows *sql.Rows) Rows {
+	return wrapRowsWithTracker(rows, nil)
+}
+
+// wrapRowsWithTracker creates a new Rows using given rows from sql, reporting its outcome to given tracker.
+func wrapRowsWithTracker(rows *sql.Rows, tracker *queryTracker) Rows {
 	return &rowsWrapper{
-		rows: rows,
+		rows:    rows,
+		tracker: tracker,
 	}
 }
 
@@ -440,7 +610,13 @@ func wrapRows(rows *sql.Rows) Rows {
 // Err should be consulted to distinguish between the two cases.
 // Every call to Scan, even the first one, must be preceded by a call to Next.
 func (r *rowsWrapper) Next() bool {
-	return r.rows.Next()
+	next := r.rows.Next()
+	if next {
+		r.tracker.row()
+	} else {
+		r.tracker.done(r.rows.Err())
+	}
+	return next
 }
 
 // Close closes the Rows, preventing further enumeration/iteration.
@@ -448,6 +624,7 @@ func (r *rowsWrapper) Next() bool {
 // and it will suffice to check the result of Err.
 func (r *rowsWrapper) Close() error {
 	err := r.rows.Close()
+	r.tracker.done(r.rows.Err())
 	if err != nil {
 		return errors.Wrap(err, "makroud: cannot close rows")
 	}
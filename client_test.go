@@ -3,6 +3,8 @@ package makroud_test
 import (
 	"context"
 	"database/sql"
+	sqldriver "database/sql/driver"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/ulule/loukoum/v3"
 
 	"github.com/ulule/makroud"
+	"github.com/ulule/makroud/hooks"
 )
 
 func TestClient_New(t *testing.T) {
@@ -381,3 +384,175 @@ func TestClient_PrepareQueryRows(t *testing.T) {
 		}
 	})
 }
+
+type span struct {
+	name       string
+	attributes map[string]string
+	err        error
+	ended      bool
+}
+
+func (s *span) End(err error) {
+	s.err = err
+	s.ended = true
+}
+
+type tracer struct {
+	mutex sync.Mutex
+	spans []*span
+}
+
+func (t *tracer) Start(ctx context.Context, name string, attributes map[string]string) (context.Context, hooks.Span) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	s := &span{name: name, attributes: attributes}
+	t.spans = append(t.spans, s)
+	return ctx, s
+}
+
+func (t *tracer) reset() []*span {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	spans := t.spans
+	t.spans = nil
+	return spans
+}
+
+func TestClient_Tracer(t *testing.T) {
+	tracer := &tracer{}
+	Setup(t, makroud.WithTracer(tracer))(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+		tracer.reset()
+
+		human := &Human{Name: "Ingeborg"}
+		err := makroud.Save(ctx, driver, human)
+		is.NoError(err)
+
+		spans := tracer.reset()
+		is.Len(spans, 1)
+		is.Equal(hooks.SpanQuery, spans[0].name)
+		is.Equal("postgresql", spans[0].attributes[hooks.AttributeSystem])
+		is.Equal("Save", spans[0].attributes[hooks.AttributeOperation])
+		is.Contains(spans[0].attributes[hooks.AttributeStatement], "INSERT INTO ztp_human")
+		is.True(spans[0].ended)
+		is.NoError(spans[0].err)
+
+		humans := []Human{}
+		err = makroud.Select(ctx, driver, &humans)
+		is.NoError(err)
+
+		spans = tracer.reset()
+		is.Len(spans, 1)
+		is.Equal("Select", spans[0].attributes[hooks.AttributeOperation])
+
+		err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+			return tx.Exec(ctx, `UPDATE ztp_human SET name = $1`, "Ingrid")
+		})
+		is.NoError(err)
+
+		spans = tracer.reset()
+		is.Len(spans, 3)
+		is.Equal(hooks.SpanTransaction, spans[0].name)
+		is.Equal(hooks.SpanExec, spans[1].name)
+		is.Equal(`UPDATE ztp_human SET name = $1`, spans[1].attributes[hooks.AttributeStatement])
+		is.Empty(spans[1].attributes[hooks.AttributeOperation])
+		is.Equal(hooks.SpanCommit, spans[2].name)
+		for i := range spans {
+			is.True(spans[i].ended)
+		}
+
+		err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+			return tx.Exec(ctx, `UPDATE ztp_human SAT name = $1`, "Ingrid")
+		})
+		is.Error(err)
+
+		spans = tracer.reset()
+		is.Len(spans, 3)
+		is.Equal(hooks.SpanExec, spans[1].name)
+		is.Error(spans[1].err)
+		is.Equal(hooks.SpanRollback, spans[2].name)
+		is.True(spans[0].ended)
+	})
+}
+
+func TestClient_Hooks(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		connector, err := pq.NewConnector(ClientOptions().String())
+		is.NoError(err)
+
+		events := []string{}
+		counts := []int{}
+
+		hooked := hooks.Wrap(connector)
+		hooked.BeforePrepare = func(ctx context.Context, query string) context.Context {
+			events = append(events, "BeforePrepare")
+			return ctx
+		}
+		hooked.AfterPrepare = func(ctx context.Context, stmt sqldriver.Stmt, err error) {
+			events = append(events, "AfterPrepare")
+		}
+		hooked.BeforeStmtExec = func(ctx context.Context, query string, args []sqldriver.NamedValue) context.Context {
+			events = append(events, "BeforeStmtExec")
+			return ctx
+		}
+		hooked.AfterStmtExec = func(ctx context.Context, result sqldriver.Result, err error) {
+			events = append(events, "AfterStmtExec")
+		}
+		hooked.BeforeStmtQuery = func(ctx context.Context, query string, args []sqldriver.NamedValue) context.Context {
+			events = append(events, "BeforeStmtQuery")
+			return ctx
+		}
+		hooked.AfterStmtQuery = func(ctx context.Context, rows sqldriver.Rows, err error) {
+			events = append(events, "AfterStmtQuery")
+		}
+		hooked.AfterRowsClose = func(ctx context.Context, count int, err error) {
+			events = append(events, "AfterRowsClose")
+			counts = append(counts, count)
+		}
+
+		node, err := makroud.ConnectWithConnector(makroud.ClientDriver, hooked)
+		is.NoError(err)
+
+		client, err := makroud.New(makroud.WithNode(node))
+		is.NoError(err)
+		is.NoError(client.Ping())
+
+		for _, name := range []string{"Ludmila", "Ilse", "Malin"} {
+			err = makroud.Save(ctx, client, &Human{Name: name})
+			is.NoError(err)
+		}
+		is.Equal([]int{1, 1, 1}, counts)
+
+		events = []string{}
+		counts = []int{}
+
+		stmt, err := client.Prepare(ctx, `UPDATE ztp_human SET name = $1 WHERE name = $2`)
+		is.NoError(err)
+		err = stmt.Exec(ctx, "Ludmilla", "Ludmila")
+		is.NoError(err)
+		is.NoError(stmt.Close())
+
+		stmt, err = client.Prepare(ctx, `SELECT name FROM ztp_human WHERE name LIKE $1`)
+		is.NoError(err)
+		rows, err := stmt.QueryRows(ctx, "%a%")
+		is.NoError(err)
+		for rows.Next() {
+			name := ""
+			is.NoError(rows.Scan(&name))
+		}
+		is.NoError(rows.Close())
+		is.NoError(stmt.Close())
+
+		is.Equal([]string{
+			"BeforePrepare", "AfterPrepare", "BeforeStmtExec", "AfterStmtExec",
+			"BeforePrepare", "AfterPrepare", "BeforeStmtQuery", "AfterStmtQuery", "AfterRowsClose",
+		}, events)
+		is.Equal([]int{2}, counts)
+
+		is.NoError(client.Close())
+	})
+}
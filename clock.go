@@ -0,0 +1,70 @@
+package makroud
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ulule/makroud/reflectx"
+)
+
+// A Clock defines the current time used for created, updated and deleted keys.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to use an ordinary function as a Clock.
+type ClockFunc func() time.Time
+
+// Now implements Clock interface.
+func (clock ClockFunc) Now() time.Time {
+	return clock()
+}
+
+// getClockTime returns the current time of driver clock, if any.
+// It's normalized to UTC with a microsecond precision, which is the precision of a PostgreSQL timestamp,
+// so the model is identical to its row.
+func getClockTime(driver Driver) (time.Time, bool) {
+	client := getClient(driver)
+	if client == nil {
+		return time.Time{}, false
+	}
+
+	clock, ok := client.clock()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return clock.Now().UTC().Truncate(time.Microsecond), true
+}
+
+// isClockKey returns if given field should be defined using driver clock on save.
+// On insert, created and updated keys are defined if they have a zero value.
+// On update, updated key is always defined.
+func isClockKey(field Field, hasPK bool, value interface{}) bool {
+	if hasPK {
+		return field.IsUpdatedKey()
+	}
+	return (field.IsCreatedKey() || field.IsUpdatedKey()) && reflectx.IsZero(value)
+}
+
+// setClockValue writes given time in the field of given model.
+func setClockValue(model Model, field Field, now time.Time) error {
+	dest := reflectx.GetReflectFieldByIndexes(reflectx.GetIndirectValue(model), field.FieldIndex())
+
+	switch value := dest.(type) {
+	case *time.Time:
+		*value = now
+		return nil
+	case **time.Time:
+		*value = &now
+		return nil
+	case sql.Scanner:
+		return value.Scan(now)
+	default:
+		return errors.Errorf("cannot use clock on %s.%s of type %s",
+			field.ModelName(), field.FieldName(), field.Type().String())
+	}
+}
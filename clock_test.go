@@ -0,0 +1,99 @@
+package makroud_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulule/loukoum/v3"
+
+	"github.com/ulule/makroud"
+)
+
+type testClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func (clock *testClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return clock.now
+}
+
+func (clock *testClock) Add(duration time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.now = clock.now.Add(duration)
+}
+
+func TestClock_Save(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+	clock := &testClock{now: time.Date(2019, 3, 14, 15, 9, 26, 535897932, location)}
+
+	Setup(t, makroud.WithClock(clock))(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		created := time.Date(2019, 3, 14, 13, 9, 26, 535897000, time.UTC)
+
+		cat := &Cat{Name: "Bagheera"}
+		err := makroud.Save(ctx, driver, cat)
+		is.NoError(err)
+		is.NotEmpty(cat.ID)
+		is.Equal(created, cat.CreatedAt)
+		is.Equal(created, cat.UpdatedAt)
+		is.False(cat.DeletedAt.Valid)
+
+		result := &Cat{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(cat.ID))
+		is.NoError(err)
+		is.True(created.Equal(result.CreatedAt))
+		is.True(created.Equal(result.UpdatedAt))
+
+		clock.Add(time.Hour)
+		updated := created.Add(time.Hour)
+
+		cat.Name = "Shere Khan"
+		err = makroud.Save(ctx, driver, cat)
+		is.NoError(err)
+		is.Equal(created, cat.CreatedAt)
+		is.Equal(updated, cat.UpdatedAt)
+
+		result = &Cat{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(cat.ID))
+		is.NoError(err)
+		is.True(created.Equal(result.CreatedAt))
+		is.True(updated.Equal(result.UpdatedAt))
+
+		clock.Add(time.Hour)
+		deleted := updated.Add(time.Hour)
+
+		err = makroud.Archive(ctx, driver, cat)
+		is.NoError(err)
+		is.True(cat.DeletedAt.Valid)
+		is.Equal(deleted, cat.DeletedAt.Time)
+
+		result = &Cat{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(cat.ID))
+		is.NoError(err)
+		is.True(result.DeletedAt.Valid)
+		is.True(deleted.Equal(result.DeletedAt.Time))
+	})
+}
+
+func TestClock_Options(t *testing.T) {
+	is := require.New(t)
+
+	options := makroud.NewClientOptions()
+	is.Nil(options.Clock)
+
+	err := makroud.WithClock(nil)(options)
+	is.Error(err)
+
+	err = makroud.WithClock(makroud.ClockFunc(time.Now))(options)
+	is.NoError(err)
+	is.NotNil(options.Clock)
+}
@@ -0,0 +1,66 @@
+package makroud
+
+import (
+	"github.com/pkg/errors"
+)
+
+// A Codec converts a field value to the value stored in its column, and back.
+// It's selected per field with the "codec" tag, such as `mk:"column:email,codec:aes"`,
+// using the name given to WithCodec.
+type Codec interface {
+	// Encode converts given field value to the value stored in database.
+	Encode(value interface{}) (interface{}, error)
+	// Decode converts given database value into dest, which is a pointer to the field.
+	// Please note that a []byte value is only valid until Decode returns: it must be copied to be retained.
+	Decode(value interface{}, dest interface{}) error
+}
+
+// codecScanner is a sql.Scanner that decodes a column into its field using a codec.
+type codecScanner struct {
+	field Field
+	codec Codec
+	dest  interface{}
+}
+
+// Scan implements sql.Scanner interface.
+func (scanner codecScanner) Scan(src interface{}) error {
+	err := scanner.codec.Decode(src, scanner.dest)
+	if err != nil {
+		return errors.Wrapf(err, "cannot decode %s.%s with codec '%s'",
+			scanner.field.ModelName(), scanner.field.FieldName(), scanner.field.CodecName())
+	}
+	return nil
+}
+
+// encodeFieldValue encodes given value using the field codec registered on driver.
+func encodeFieldValue(driver Driver, field Field, value interface{}) (interface{}, error) {
+	codec, err := getFieldCodec(driver, field)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err = codec.Encode(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot encode %s.%s with codec '%s'",
+			field.ModelName(), field.FieldName(), field.CodecName())
+	}
+	return value, nil
+}
+
+// getFieldCodec returns the codec of given field registered on driver.
+// Codecs are looked up on each use, since a schema is cached and shared by every driver.
+func getFieldCodec(driver Driver, field Field) (Codec, error) {
+	client := getClient(driver)
+	if client == nil {
+		return nil, errors.Errorf("codec '%s' of %s.%s requires a driver",
+			field.CodecName(), field.ModelName(), field.FieldName())
+	}
+
+	codec, ok := client.codec(field.CodecName())
+	if !ok {
+		return nil, errors.Errorf("codec '%s' of %s.%s is not registered",
+			field.CodecName(), field.ModelName(), field.FieldName())
+	}
+
+	return codec, nil
+}
@@ -0,0 +1,173 @@
+package makroud_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/ulule/loukoum/v3"
+
+	"github.com/ulule/makroud"
+)
+
+type rot13Codec struct{}
+
+func (rot13Codec) rotate(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 'a' + (r-'a'+13)%26
+		case r >= 'A' && r <= 'Z':
+			return 'A' + (r-'A'+13)%26
+		default:
+			return r
+		}
+	}, value)
+}
+
+func (codec rot13Codec) Encode(value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, errors.Errorf("cannot encode %T", value)
+	}
+	if str == "" {
+		return nil, nil
+	}
+	return codec.rotate(str), nil
+}
+
+func (codec rot13Codec) Decode(value interface{}, dest interface{}) error {
+	str, ok := dest.(*string)
+	if !ok {
+		return errors.Errorf("cannot decode into %T", dest)
+	}
+	switch value := value.(type) {
+	case nil:
+		*str = ""
+	case []byte:
+		*str = codec.rotate(string(value))
+	case string:
+		*str = codec.rotate(value)
+	default:
+		return errors.Errorf("cannot decode %T", value)
+	}
+	return nil
+}
+
+type failingCodec struct{}
+
+func (failingCodec) Encode(value interface{}) (interface{}, error) {
+	return nil, errors.New("key not found")
+}
+
+func (failingCodec) Decode(value interface{}, dest interface{}) error {
+	return errors.New("key not found")
+}
+
+func TestCodec_Save(t *testing.T) {
+	Setup(t, makroud.WithCodec("rot13", rot13Codec{}))(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		schema, err := makroud.GetSchema(driver, &Informant{})
+		is.NoError(err)
+		is.True(schema.HasColumn("name"))
+
+		informant := &Informant{Name: "Finnick"}
+		err = makroud.Save(ctx, driver, informant)
+		is.NoError(err)
+		is.NotZero(informant.ID)
+
+		name := ""
+		err = makroud.RawExecArgs(ctx, driver, "SELECT name FROM ztp_informant WHERE id = $1",
+			[]interface{}{informant.ID}, &name)
+		is.NoError(err)
+		is.Equal("Svaavpx", name)
+
+		count, err := makroud.Count(ctx, driver,
+			loukoum.Select(loukoum.Count("id")).From("ztp_informant").
+				Where(loukoum.Condition("alias").IsNull(true)))
+		is.NoError(err)
+		is.Equal(int64(1), count)
+
+		result := &Informant{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(informant.ID))
+		is.NoError(err)
+		is.Equal("Finnick", result.Name)
+		is.Empty(result.Alias)
+	})
+}
+
+func TestCodec_Errors(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		_, err := makroud.GetSchema(driver, &Informant{})
+		is.NoError(err)
+
+		informant := &Informant{Name: "Finnick"}
+		err = makroud.Save(ctx, driver, informant)
+		is.Error(err)
+		is.Contains(err.Error(), "codec 'rot13' of Informant.Name is not registered")
+
+		_, err = makroud.New(Options(makroud.WithCodec("", rot13Codec{}))...)
+		is.Error(err)
+
+		_, err = makroud.New(Options(makroud.WithCodec("rot13", nil))...)
+		is.Error(err)
+	})
+
+	Setup(t, makroud.WithCodec("rot13", failingCodec{}))(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		informant := &Informant{Name: "Finnick"}
+		err := makroud.Save(ctx, driver, informant)
+		is.Error(err)
+		is.Contains(err.Error(), "cannot encode Informant.Name with codec 'rot13': key not found")
+
+		driver.MustExec(ctx, "INSERT INTO ztp_informant (name) VALUES ('Svaavpx')")
+
+		result := &Informant{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("name").Equal("Svaavpx"))
+		is.Error(err)
+		is.Contains(err.Error(), "cannot decode Informant.Name with codec 'rot13': key not found")
+	})
+}
+
+func TestCodec_SharedCache(t *testing.T) {
+	Setup(t, makroud.WithCodec("rot13", rot13Codec{}))(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		other, err := makroud.New(Options(makroud.WithCodec("rot13", failingCodec{}))...)
+		is.NoError(err)
+		defer func() {
+			is.NoError(other.Close())
+		}()
+		other.SetCache(driver.GetCache())
+
+		_, err = makroud.GetSchema(driver, &Informant{})
+		is.NoError(err)
+
+		informant := &Informant{Name: "Finnick"}
+		err = makroud.Save(ctx, other, informant)
+		is.Error(err)
+		is.Contains(err.Error(), "cannot encode Informant.Name with codec 'rot13': key not found")
+
+		err = makroud.Save(ctx, driver, informant)
+		is.NoError(err)
+
+		result := &Informant{}
+		err = makroud.Select(ctx, other, result, loukoum.Condition("id").Equal(informant.ID))
+		is.Error(err)
+		is.Contains(err.Error(), "cannot decode Informant.Name with codec 'rot13': key not found")
+
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(informant.ID))
+		is.NoError(err)
+		is.Equal("Finnick", result.Name)
+	})
+}
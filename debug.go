@@ -212,6 +212,18 @@ func debugField(field Field) debugWriter {
 			k: "is_excluded",
 			v: strconv.FormatBool(field.IsExcluded()),
 		},
+		debugValue{
+			k: "is_json",
+			v: strconv.FormatBool(field.IsJSON()),
+		},
+		debugValue{
+			k: "is_array",
+			v: strconv.FormatBool(field.IsArray()),
+		},
+		debugValue{
+			k: "codec_name",
+			v: field.CodecName(),
+		},
 		debugValue{
 			k: "has_default",
 			v: strconv.FormatBool(field.HasDefault()),
@@ -228,6 +240,10 @@ func debugField(field Field) debugWriter {
 			k: "has_uuid_v4",
 			v: strconv.FormatBool(field.HasUUIDV4()),
 		},
+		debugValue{
+			k: "pk_generator",
+			v: field.PrimaryKeyGenerator(),
+		},
 		debugValue{
 			k: "is_created_key",
 			v: strconv.FormatBool(field.IsCreatedKey()),
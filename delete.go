@@ -5,10 +5,13 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/ulule/loukoum/v3"
+
+	"github.com/ulule/makroud/hooks"
 )
 
 // Delete deletes the given instance.
 func Delete(ctx context.Context, driver Driver, model Model) error {
+	ctx = hooks.WithOperation(ctx, "Delete")
 	err := remove(ctx, driver, model)
 	if err != nil {
 		return errors.Wrap(err, "makroud: cannot execute delete")
@@ -18,6 +21,7 @@ func Delete(ctx context.Context, driver Driver, model Model) error {
 
 // Archive archives the given instance.
 func Archive(ctx context.Context, driver Driver, model Model) error {
+	ctx = hooks.WithOperation(ctx, "Archive")
 	err := archive(ctx, driver, model)
 	if err != nil {
 		return errors.Wrap(err, "makroud: cannot execute archive")
@@ -44,6 +48,14 @@ func remove(ctx context.Context, driver Driver, model Model) error {
 	builder := loukoum.Delete(schema.TableName()).
 		Where(loukoum.Condition(pk.ColumnName()).Equal(id))
 
+	tenant, err := getTenantCondition(ctx, driver, schema)
+	if err != nil {
+		return errors.Wrapf(err, "%T cannot be deleted", model)
+	}
+	if tenant != nil {
+		builder = builder.And(tenant)
+	}
+
 	return Exec(ctx, driver, builder)
 }
 
@@ -67,10 +79,29 @@ func archive(ctx context.Context, driver Driver, model Model) error {
 		return errors.Wrapf(err, "%T cannot be archived", model)
 	}
 
+	var deletedAt interface{} = loukoum.Raw("NOW()")
+
+	now, ok := getClockTime(driver)
+	if ok {
+		err = setClockValue(model, *schema.deletedKey, now)
+		if err != nil {
+			return errors.Wrapf(err, "%T cannot be archived", model)
+		}
+		deletedAt = now
+	}
+
 	builder := loukoum.Update(schema.TableName()).
-		Set(loukoum.Pair(schema.DeletedKeyName(), loukoum.Raw("NOW()"))).
+		Set(loukoum.Pair(schema.DeletedKeyName(), deletedAt)).
 		Where(loukoum.Condition(pk.ColumnName()).Equal(id)).
 		Returning(schema.DeletedKeyName())
 
+	tenant, err := getTenantCondition(ctx, driver, schema)
+	if err != nil {
+		return errors.Wrapf(err, "%T cannot be archived", model)
+	}
+	if tenant != nil {
+		builder = builder.And(tenant)
+	}
+
 	return Exec(ctx, driver, builder)
 }
@@ -0,0 +1,126 @@
+package makroud
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/ulule/makroud/reflectx"
+)
+
+// structField is a struct field with its full index path from the root type.
+// Fields of an embedded struct are flattened with a column prefix, if any.
+type structField struct {
+	reflect.StructField
+	// name defines the field name from the root type, such as "Billing.Street" for a named embedded struct.
+	name string
+	// prefix defines the column prefix inherited from an embedded struct.
+	prefix string
+	// nested defines if this field belongs to a named embedded struct, and is therefore not promoted.
+	nested bool
+}
+
+// getStructFields returns the exported fields of given type.
+// Anonymous embedded structs, and named struct fields tagged with "embed", are flattened into their parent
+// using their full index path.
+func getStructFields(value interface{}) ([]structField, error) {
+	rtype, ok := value.(reflect.Type)
+	if !ok {
+		rtype = reflectx.GetIndirectValue(value).Type()
+	}
+
+	if rtype.Kind() != reflect.Struct {
+		return nil, errors.New("makroud: cannot find fields on a non-struct interface")
+	}
+
+	return flattenStructFields(rtype, structField{}, map[reflect.Type]bool{})
+}
+
+func flattenStructFields(rtype reflect.Type, parent structField, visited map[reflect.Type]bool) ([]structField, error) {
+	if visited[rtype] {
+		return nil, errors.Errorf("makroud: recursive embedded struct %s", rtype.String())
+	}
+	visited[rtype] = true
+	defer delete(visited, rtype)
+
+	fields := []structField{}
+	for i := 0; i < rtype.NumField(); i++ {
+		field := structField{
+			StructField: rtype.Field(i),
+			prefix:      parent.prefix,
+			nested:      parent.nested,
+		}
+
+		field.Index = append(append([]int{}, parent.Index...), field.Index...)
+		field.name = field.Name
+		if parent.nested {
+			field.name = parent.name + "." + field.Name
+		}
+
+		tags := GetTags(field.StructField)
+		if tags.HasKey(TagName, TagKeyIgnored) {
+			continue
+		}
+
+		embedded, err := isEmbeddedStructField(field.StructField, tags)
+		if err != nil {
+			return nil, err
+		}
+
+		if !embedded {
+			// Ignore private field...
+			if field.PkgPath == "" {
+				fields = append(fields, field)
+			}
+			continue
+		}
+
+		field.prefix += tags.GetByKey(TagName, TagKeyPrefix)
+		field.nested = field.nested || !field.Anonymous
+
+		children, err := flattenStructFields(reflectx.GetIndirectType(field.Type), field, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, children...)
+	}
+
+	return fields, nil
+}
+
+// isEmbeddedStructField returns if given field is a struct that should be flattened into its parent.
+func isEmbeddedStructField(field reflect.StructField, tags Tags) (bool, error) {
+	rtype := field.Type
+	if rtype.Kind() == reflect.Ptr {
+		rtype = rtype.Elem()
+	}
+
+	isStruct := rtype.Kind() == reflect.Struct && !reflectx.IsScannable(rtype)
+
+	// A JSON field is stored in a single column.
+	if tags.HasKey(TagName, TagKeyJSON) {
+		return false, nil
+	}
+
+	if tags.HasKey(TagName, TagKeyEmbed) {
+		if !isStruct {
+			return false, errors.Errorf("makroud: field '%s' must be a struct to be embedded", field.Name)
+		}
+		if field.PkgPath != "" && !field.Anonymous {
+			return false, errors.Errorf("makroud: field '%s' must be exported to be embedded", field.Name)
+		}
+		return true, nil
+	}
+
+	if !field.Anonymous || !isStruct {
+		return false, nil
+	}
+
+	// An unexported embedded pointer cannot be allocated on scan.
+	if field.PkgPath != "" && field.Type.Kind() == reflect.Ptr {
+		return false, nil
+	}
+
+	return true, nil
+}
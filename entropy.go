@@ -0,0 +1,52 @@
+package makroud
+
+import (
+	"io"
+	"sync"
+
+	"github.com/oklog/ulid"
+)
+
+// MonotonicEntropy is an entropy source safe for concurrent use, which is used by default to generate ULID.
+// Every ULID generated with the same MonotonicEntropy is strictly greater than the previous one, even within
+// the same millisecond.
+type MonotonicEntropy struct {
+	mutex   sync.Mutex
+	entropy io.Reader
+	ms      uint64
+}
+
+// NewMonotonicEntropy returns a new MonotonicEntropy using given entropy source, which must yield random bytes.
+func NewMonotonicEntropy(entropy io.Reader) *MonotonicEntropy {
+	return &MonotonicEntropy{
+		entropy: ulid.Monotonic(entropy, 0),
+	}
+}
+
+// Read implements io.Reader interface.
+func (e *MonotonicEntropy) Read(p []byte) (int, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.entropy.Read(p)
+}
+
+// New returns a new ULID, strictly greater than the previous one generated with this entropy source.
+// If the clock goes backward, the timestamp of the previous ULID is reused.
+func (e *MonotonicEntropy) New() (ulid.ULID, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	ms := ulid.Now()
+	if ms < e.ms {
+		ms = e.ms
+	}
+
+	id, err := ulid.New(ms, e.entropy)
+	if err != nil {
+		return id, err
+	}
+
+	e.ms = ms
+	return id, nil
+}
@@ -0,0 +1,74 @@
+package makroud_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/oklog/ulid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/makroud"
+)
+
+func TestEntropy_Monotonic(t *testing.T) {
+	is := require.New(t)
+
+	workers := 8
+	iterations := 2000
+
+	entropy := makroud.NewMonotonicEntropy(rand.New(rand.NewSource(42)))
+
+	results := make([][]ulid.ULID, workers)
+	wg := &sync.WaitGroup{}
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				id, err := entropy.New()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[worker] = append(results[worker], id)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	all := map[ulid.ULID]bool{}
+	for _, ids := range results {
+		is.Len(ids, iterations)
+		for j := range ids {
+			if j > 0 {
+				is.Equal(1, ids[j].Compare(ids[j-1]), "ulid must be strictly increasing")
+			}
+			is.False(all[ids[j]], "ulid must be unique")
+			all[ids[j]] = true
+		}
+	}
+
+	buffer := make([]byte, 16)
+	n, err := entropy.Read(buffer)
+	is.NoError(err)
+	is.Equal(16, n)
+}
+
+func TestEntropy_GenerateULID(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		is := require.New(t)
+
+		_, ok := driver.Entropy().(*makroud.MonotonicEntropy)
+		is.True(ok)
+
+		previous := ""
+		for i := 0; i < 1000; i++ {
+			id := makroud.GenerateULID(driver)
+			is.True(id > previous, "ulid must be strictly increasing")
+			previous = id
+		}
+	})
+}
@@ -42,4 +42,6 @@ var (
 	ErrSliceOfScalarMultipleColumns = fmt.Errorf("slice of scalar with multiple columns")
 	// ErrCommitNotInTransaction is returned when using commit outside of a transaction.
 	ErrCommitNotInTransaction = fmt.Errorf("cannot commit outside of a transaction")
+	// ErrTenantRequired is returned when a schema requires a tenant but none is defined in context.
+	ErrTenantRequired = fmt.Errorf("a tenant is required in context")
 )
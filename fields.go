@@ -35,11 +35,15 @@ type Field struct {
 	isForeignKey    bool
 	isAssociation   bool
 	isExcluded      bool
+	isJSON          bool
+	isArray         bool
+	codecName       string
 	hasRelation     bool
 	hasDefault      bool
 	hasULID         bool
 	hasUUIDV1       bool
 	hasUUIDV4       bool
+	pkGenerator     string
 	isCreatedKey    bool
 	isUpdatedKey    bool
 	isDeletedKey    bool
@@ -87,6 +91,26 @@ func (field Field) IsExcluded() bool {
 	return field.isExcluded
 }
 
+// IsJSON returns if the field is stored as a JSON document.
+func (field Field) IsJSON() bool {
+	return field.isJSON
+}
+
+// IsArray returns if the field is stored in an array column.
+func (field Field) IsArray() bool {
+	return field.isArray
+}
+
+// HasCodec returns if the field is encoded and decoded with a codec.
+func (field Field) HasCodec() bool {
+	return field.codecName != ""
+}
+
+// CodecName returns the name of the codec used by this field.
+func (field Field) CodecName() string {
+	return field.codecName
+}
+
 // IsForeignKey returns if the field is a foreign key.
 func (field Field) IsForeignKey() bool {
 	return field.isForeignKey
@@ -137,6 +161,11 @@ func (field Field) HasUUIDV4() bool {
 	return field.hasUUIDV4
 }
 
+// PrimaryKeyGenerator returns the name of the generator used for it's primary key, if any.
+func (field Field) PrimaryKeyGenerator() string {
+	return field.pkGenerator
+}
+
 // IsCreatedKey returns if the field is a created key.
 func (field Field) IsCreatedKey() bool {
 	return field.isCreatedKey
@@ -178,20 +207,37 @@ func NewField(driver Driver, schema *Schema, model Model, name string, args ...M
 		return nil, errors.Errorf("field '%s' not found in model", name)
 	}
 
-	tags := GetTags(field)
+	return newField(driver, model, structField{StructField: field, name: field.Name}, opts)
+}
+
+// newField creates a new field using given model and struct field, which may be flattened from an embedded struct.
+func newField(driver Driver, model Model, field structField, opts ModelOpts) (*Field, error) {
+	tags := GetTags(field.StructField)
 
 	rtype := field.Type
 	if rtype.Kind() == reflect.Ptr {
 		rtype = rtype.Elem()
 	}
 
+	isJSON := tags.HasKey(TagName, TagKeyJSON)
+	codecName := tags.GetByKey(TagName, TagKeyCodec)
+	if isJSON && codecName != "" {
+		return nil, errors.Errorf("field '%s' cannot have a codec and be a JSON document", field.name)
+	}
+
+	isArray := !isJSON && codecName == "" && (tags.HasKey(TagName, TagKeyArray) || isArrayType(rtype))
+	if isArray && rtype.Kind() != reflect.Slice && rtype.Kind() != reflect.Array {
+		return nil, errors.Errorf("field '%s' must be a slice to be an array", field.name)
+	}
+
 	modelName := reflectx.GetIndirectTypeName(model)
 	tableName := model.TableName()
 
 	columnName := tags.GetByKey(TagName, TagKeyColumn)
 	if columnName == "" {
-		columnName = snaker.CamelToSnake(name)
+		columnName = snaker.CamelToSnake(field.Name)
 	}
+	columnName = field.prefix + columnName
 
 	columnPath := fmt.Sprintf("%s.%s", tableName, columnName)
 
@@ -204,6 +250,11 @@ func NewField(driver Driver, schema *Schema, model Model, name string, args ...M
 	hasUUIDV1 := tags.GetByKey(TagName, TagKeyPrimaryKey) == TagKeyUUIDV1
 	hasUUIDV4 := tags.GetByKey(TagName, TagKeyPrimaryKey) == TagKeyUUIDV4
 
+	pkGenerator := tags.GetByKey(TagName, TagKeyPrimaryKey)
+	if pkGenerator == "true" || pkGenerator == TagKeyDB {
+		pkGenerator = ""
+	}
+
 	isCreatedKey := columnName == opts.CreatedKey
 	isUpdatedKey := columnName == opts.UpdatedKey
 	isDeletedKey := columnName == opts.DeletedKey
@@ -213,7 +264,7 @@ func NewField(driver Driver, schema *Schema, model Model, name string, args ...M
 	instance := &Field{
 		modelName:    modelName,
 		tableName:    tableName,
-		fieldName:    field.Name,
+		fieldName:    field.name,
 		fieldIndex:   field.Index,
 		columnName:   columnName,
 		columnPath:   columnPath,
@@ -221,6 +272,9 @@ func NewField(driver Driver, schema *Schema, model Model, name string, args ...M
 		isForeignKey: isForeignKey,
 		foreignKey:   foreignKey,
 		isExcluded:   isExcluded,
+		isJSON:       isJSON,
+		isArray:      isArray,
+		codecName:    codecName,
 		isCreatedKey: isCreatedKey,
 		isUpdatedKey: isUpdatedKey,
 		isDeletedKey: isDeletedKey,
@@ -228,15 +282,23 @@ func NewField(driver Driver, schema *Schema, model Model, name string, args ...M
 		hasULID:      hasULID,
 		hasUUIDV1:    hasUUIDV1,
 		hasUUIDV4:    hasUUIDV4,
+		pkGenerator:  pkGenerator,
 		rtype:        rtype,
 	}
 
 	// Early return if the field type is not an association.
 	reference := toModel(rtype)
-	if reference == nil {
+	if reference == nil || isJSON || isArray || codecName != "" {
+		if field.nested && (isPrimaryKey || isForeignKey) {
+			return nil, errors.Errorf("field '%s' cannot be a key in a named embedded struct", field.name)
+		}
 		return instance, nil
 	}
 
+	if field.nested {
+		return nil, errors.Errorf("field '%s' cannot be a association in a named embedded struct", field.name)
+	}
+
 	return getFieldAssocitationType(driver, instance, rtype, reference, tags)
 }
 
@@ -272,6 +334,9 @@ func getFieldAssocitationType(driver Driver, instance *Field, rtype reflect.Type
 	instance.isDeletedKey = false
 	instance.hasDefault = false
 	instance.hasULID = false
+	instance.hasUUIDV1 = false
+	instance.hasUUIDV4 = false
+	instance.pkGenerator = ""
 	instance.hasRelation = hasRelation
 	instance.relationName = relationName
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/ulule/loukoum/v3/builder"
 
 	"github.com/ulule/makroud/reflectx"
 )
@@ -257,6 +258,16 @@ func (object ReferenceObject) DeletedKeyPath() string {
 	return object.schema.DeletedKeyPath()
 }
 
+// HasDefaultScope returns if a default scope is defined for this reference.
+func (object ReferenceObject) HasDefaultScope() bool {
+	return object.schema.HasDefaultScope()
+}
+
+// DefaultScope applies the reference schema default scope on given query.
+func (object ReferenceObject) DefaultScope(query builder.Select) builder.Select {
+	return object.schema.DefaultScope(query)
+}
+
 // NewReference creates a reference from a field instance.
 func NewReference(driver Driver, local *Schema, field *Field) (*Reference, error) {
 	reference := toModel(field.rtype)
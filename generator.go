@@ -0,0 +1,70 @@
+package makroud
+
+import (
+	"github.com/pkg/errors"
+)
+
+// A PrimaryKeyGenerator defines a primary key value when a model is inserted.
+// It's selected per model with the "pk" tag, such as `mk:"column:id,pk:snowflake"`,
+// using the name given to WithPrimaryKeyGenerator.
+type PrimaryKeyGenerator interface {
+	// Generate returns a new primary key value.
+	Generate(driver Driver) (interface{}, error)
+}
+
+// PrimaryKeyGeneratorFunc is an adapter to use an ordinary function as a PrimaryKeyGenerator.
+type PrimaryKeyGeneratorFunc func(driver Driver) (interface{}, error)
+
+// Generate implements PrimaryKeyGenerator interface.
+func (generator PrimaryKeyGeneratorFunc) Generate(driver Driver) (interface{}, error) {
+	return generator(driver)
+}
+
+// getPrimaryKeyGenerators returns the generators available on a client: the built-in ones
+// and those registered with given options, which takes precedence.
+func getPrimaryKeyGenerators(options *ClientOptions) map[string]PrimaryKeyGenerator {
+	generators := map[string]PrimaryKeyGenerator{
+		TagKeyULID: PrimaryKeyGeneratorFunc(func(driver Driver) (interface{}, error) {
+			return GenerateULID(driver), nil
+		}),
+		TagKeyUUIDV1: PrimaryKeyGeneratorFunc(func(driver Driver) (interface{}, error) {
+			return GenerateUUIDV1(driver), nil
+		}),
+		TagKeyUUIDV4: PrimaryKeyGeneratorFunc(func(driver Driver) (interface{}, error) {
+			return GenerateUUIDV4(driver), nil
+		}),
+	}
+
+	for name, generator := range options.PrimaryKeyGenerators {
+		generators[name] = generator
+	}
+
+	return generators
+}
+
+// generatePrimaryKey returns a new value for given primary key, using the generator registered on driver.
+func generatePrimaryKey(driver Driver, pk PrimaryKey) (interface{}, error) {
+	name := pk.PrimaryKeyGenerator()
+
+	client := getClient(driver)
+	if client == nil {
+		return nil, errors.Errorf("primary key generator '%s' of %s requires a driver", name, pk.ModelName())
+	}
+
+	generator, ok := client.generator(name)
+	if !ok {
+		return nil, errors.Errorf("primary key generator '%s' of %s is not registered", name, pk.ModelName())
+	}
+
+	value, err := generator.Generate(driver)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot generate primary key of %s with '%s'", pk.ModelName(), name)
+	}
+
+	value, err = toKeyValue(pk.Field, value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot use generated primary key of %s", pk.ModelName())
+	}
+
+	return value, nil
+}
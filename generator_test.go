@@ -0,0 +1,98 @@
+package makroud_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/ulule/loukoum/v3"
+
+	"github.com/ulule/makroud"
+)
+
+func TestGenerator_Save(t *testing.T) {
+	sequence := int64(1 << 40)
+
+	options := []makroud.Option{
+		makroud.WithPrimaryKeyGenerator("ticket", makroud.PrimaryKeyGeneratorFunc(
+			func(driver makroud.Driver) (interface{}, error) {
+				return "tkt_" + strings.ToLower(makroud.GenerateULID(driver)), nil
+			},
+		)),
+		makroud.WithPrimaryKeyGenerator("snowflake", makroud.PrimaryKeyGeneratorFunc(
+			func(driver makroud.Driver) (interface{}, error) {
+				return atomic.AddInt64(&sequence, 1), nil
+			},
+		)),
+	}
+
+	Setup(t, options...)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		ticket := &Ticket{Violation: "Parking in a no-parking zone"}
+		err := makroud.Save(ctx, driver, ticket)
+		is.NoError(err)
+		is.True(strings.HasPrefix(ticket.ID, "tkt_"))
+		is.Len(ticket.ID, 30)
+
+		result := &Ticket{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(ticket.ID))
+		is.NoError(err)
+		is.Equal(ticket.Violation, result.Violation)
+
+		fine := &Fine{Amount: 100}
+		err = makroud.Save(ctx, driver, fine)
+		is.NoError(err)
+		is.Equal(int64(1<<40)+1, fine.ID)
+
+		fine.Amount = 200
+		err = makroud.Save(ctx, driver, fine)
+		is.NoError(err)
+		is.Equal(int64(1<<40)+1, fine.ID)
+		is.Equal(int64(1<<40)+1, atomic.LoadInt64(&sequence))
+
+		count, err := makroud.Count(ctx, driver, loukoum.Select(loukoum.Count("id")).From("ztp_fine").
+			Where(loukoum.Condition("id").Equal(fine.ID)).And(loukoum.Condition("amount").Equal(200)))
+		is.NoError(err)
+		is.Equal(int64(1), count)
+	})
+}
+
+func TestGenerator_Errors(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		err := makroud.Save(ctx, driver, &Ticket{Violation: "Speeding"})
+		is.Error(err)
+		is.Contains(err.Error(), "primary key generator 'ticket' of Ticket is not registered")
+
+		_, err = makroud.New(Options(makroud.WithPrimaryKeyGenerator("", makroud.PrimaryKeyGeneratorFunc(nil)))...)
+		is.Error(err)
+
+		_, err = makroud.New(Options(makroud.WithPrimaryKeyGenerator("db", makroud.PrimaryKeyGeneratorFunc(nil)))...)
+		is.Error(err)
+
+		_, err = makroud.New(Options(makroud.WithPrimaryKeyGenerator("ticket", nil))...)
+		is.Error(err)
+	})
+
+	generator := makroud.WithPrimaryKeyGenerator("ticket", makroud.PrimaryKeyGeneratorFunc(
+		func(driver makroud.Driver) (interface{}, error) {
+			return nil, errors.New("clock drift")
+		},
+	))
+
+	Setup(t, generator)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		err := makroud.Save(ctx, driver, &Ticket{Violation: "Speeding"})
+		is.Error(err)
+		is.Contains(err.Error(), "cannot generate primary key of Ticket with 'ticket': clock drift")
+	})
+}
@@ -0,0 +1,69 @@
+//go:build go1.14
+// +build go1.14
+
+package makroud_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/ulule/loukoum/v3"
+
+	"github.com/ulule/makroud"
+	"github.com/ulule/makroud/makroudtest"
+)
+
+func TestHarness(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		is := require.New(t)
+
+		harness, err := makroudtest.NewHarness(Options()...)
+		is.NoError(err)
+		defer func() {
+			is.NoError(harness.Close())
+		}()
+
+		count := func(ctx context.Context, is *require.Assertions, driver makroud.Driver) int64 {
+			count, err := makroud.Count(ctx, driver, loukoum.Select(loukoum.Count("id")).From("ztp_cat"))
+			is.NoError(err)
+			return count
+		}
+
+		t.Run("group", func(t *testing.T) {
+			for _, name := range []string{"Oliver", "Dodger"} {
+				name := name
+
+				t.Run(name, func(t *testing.T) {
+					t.Parallel()
+
+					ctx := context.Background()
+					is := require.New(t)
+					tx := harness.Begin(t)
+
+					err := makroud.Save(ctx, tx, &Cat{Name: name})
+					is.NoError(err)
+					is.Equal(int64(1), count(ctx, is, tx))
+
+					err = makroud.Transaction(ctx, tx, nil, func(tx makroud.Driver) error {
+						return makroud.Save(ctx, tx, &Cat{Name: name + " Jr."})
+					})
+					is.NoError(err)
+					is.Equal(int64(2), count(ctx, is, tx))
+
+					err = makroud.Transaction(ctx, tx, nil, func(tx makroud.Driver) error {
+						err := makroud.Save(ctx, tx, &Cat{Name: name + " III"})
+						is.NoError(err)
+						is.Equal(int64(3), count(ctx, is, tx))
+						return errors.New("rollback")
+					})
+					is.Error(err)
+					is.Equal(int64(2), count(ctx, is, tx))
+				})
+			}
+		})
+
+		is.Equal(int64(0), count(context.Background(), is, harness.Client()))
+	})
+}
@@ -5,17 +5,21 @@ import (
 	"database/sql"
 	"io"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/ulule/loukoum/v3/builder"
 
+	"github.com/ulule/makroud/hooks"
 	"github.com/ulule/makroud/reflectx"
 )
 
 // Exec will execute given query from a Loukoum builder.
 // If an object is given, it will mutate it to match the row values.
 func Exec(ctx context.Context, driver Driver, stmt builder.Builder, dest ...interface{}) error {
+	ctx = hooks.WithOperation(ctx, "Exec")
 	if driver.HasLogger() {
 		start := time.Now()
 		query := NewQuery(stmt)
@@ -27,17 +31,13 @@ func Exec(ctx context.Context, driver Driver, stmt builder.Builder, dest ...inte
 
 	query, args := stmt.Query()
 
-	err := exec(ctx, driver, query, args, dest...)
-	if err != nil {
-		return errors.Wrap(err, "makroud: cannot execute query")
-	}
-
-	return nil
+	return execQuery(ctx, driver, query, args, dest...)
 }
 
 // RawExec will execute given query.
 // If an object is given, it will mutate it to match the row values.
 func RawExec(ctx context.Context, driver Driver, query string, dest ...interface{}) error {
+	ctx = hooks.WithOperation(ctx, "Exec")
 	if driver.HasLogger() {
 		start := time.Now()
 		query := NewRawQuery(query)
@@ -47,17 +47,13 @@ func RawExec(ctx context.Context, driver Driver, query string, dest ...interface
 		}()
 	}
 
-	err := exec(ctx, driver, query, nil, dest...)
-	if err != nil {
-		return errors.Wrap(err, "makroud: cannot execute query")
-	}
-
-	return nil
+	return execQuery(ctx, driver, query, nil, dest...)
 }
 
 // RawExecArgs will execute given query with given arguments.
 // If an object is given, it will mutate it to match the row values.
 func RawExecArgs(ctx context.Context, driver Driver, query string, args []interface{}, dest ...interface{}) error {
+	ctx = hooks.WithOperation(ctx, "Exec")
 	if driver.HasLogger() {
 		start := time.Now()
 		query := Query{
@@ -71,19 +67,23 @@ func RawExecArgs(ctx context.Context, driver Driver, query string, args []interf
 		}()
 	}
 
-	err := exec(ctx, driver, query, args, dest...)
-	if err != nil {
-		return errors.Wrap(err, "makroud: cannot execute query")
-	}
-
-	return nil
+	return execQuery(ctx, driver, query, args, dest...)
 }
 
 // Count will execute the given query to return a number from an aggregate function.
-func Count(ctx context.Context, driver Driver, stmt builder.Builder) (int64, error) {
+// Scopes given as arguments are applied in order on the query.
+// If a model is given as argument, its default scope and its deleted key filter are applied on the query,
+// unless Unscoped is also given as argument. Its tenant predicate, if any, is always applied.
+func Count(ctx context.Context, driver Driver, stmt builder.Builder, args ...interface{}) (int64, error) {
+	ctx = hooks.WithOperation(ctx, "Count")
 	count := int64(0)
 
-	err := Exec(ctx, driver, stmt, &count)
+	stmt, err := parseCountArgs(ctx, driver, stmt, args)
+	if err != nil {
+		return 0, err
+	}
+
+	err = Exec(ctx, driver, stmt, &count)
 	if IsErrNoRows(err) {
 		return 0, nil
 	}
@@ -95,10 +95,19 @@ func Count(ctx context.Context, driver Driver, stmt builder.Builder) (int64, err
 }
 
 // FloatCount will execute given query to return a number (in float) from a aggregate function.
-func FloatCount(ctx context.Context, driver Driver, stmt builder.Builder) (float64, error) {
+// Scopes given as arguments are applied in order on the query.
+// If a model is given as argument, its default scope and its deleted key filter are applied on the query,
+// unless Unscoped is also given as argument. Its tenant predicate, if any, is always applied.
+func FloatCount(ctx context.Context, driver Driver, stmt builder.Builder, args ...interface{}) (float64, error) {
+	ctx = hooks.WithOperation(ctx, "Count")
 	count := float64(0)
 
-	err := Exec(ctx, driver, stmt, &count)
+	stmt, err := parseCountArgs(ctx, driver, stmt, args)
+	if err != nil {
+		return 0, err
+	}
+
+	err = Exec(ctx, driver, stmt, &count)
 	if IsErrNoRows(err) {
 		return 0, nil
 	}
@@ -109,6 +118,55 @@ func FloatCount(ctx context.Context, driver Driver, stmt builder.Builder) (float
 	return count, nil
 }
 
+// parseCountArgs applies the scopes, and the automatic scopes of the models, given as arguments on query.
+func parseCountArgs(ctx context.Context, driver Driver,
+	query builder.Builder, args []interface{}) (builder.Builder, error) {
+	if len(args) == 0 {
+		return query, nil
+	}
+
+	selector, ok := query.(builder.Select)
+	if !ok {
+		return nil, errors.Errorf("makroud: cannot apply scopes on %T", query)
+	}
+
+	models := []Model{}
+	unscoped := false
+
+	for i := range args {
+		switch v := args[i].(type) {
+		case Model:
+			models = append(models, v)
+		case Scope:
+			selector = v(selector)
+		case func(query builder.Select) builder.Select:
+			selector = v(selector)
+		case UnscopedArg:
+			unscoped = true
+		default:
+			return nil, errors.Errorf("makroud: unsupported argument %T", v)
+		}
+	}
+
+	for _, model := range models {
+		schema, err := GetSchema(driver, model)
+		if err != nil {
+			return nil, errors.Wrapf(err, "makroud: cannot fetch schema informations on %T", model)
+		}
+
+		if !unscoped {
+			selector = applySchemaScopes(schema, selector)
+		}
+
+		selector, err = applyTenantScope(ctx, driver, schema, selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "makroud: cannot execute query on %T", model)
+		}
+	}
+
+	return selector, nil
+}
+
 // IsErrNoRows returns if given error is a "no rows" error.
 func IsErrNoRows(err error) bool {
 	if err == nil {
@@ -118,6 +176,139 @@ func IsErrNoRows(err error) bool {
 	return err == sql.ErrNoRows || err == ErrNoRows
 }
 
+// List of PostgreSQL error codes (SQLSTATE) handled by makroud.
+const (
+	UniqueViolationCode      = "23505"
+	ForeignKeyViolationCode  = "23503"
+	NotNullViolationCode     = "23502"
+	SerializationFailureCode = "40001"
+	DeadlockDetectedCode     = "40P01"
+	QueryCanceledCode        = "57014"
+)
+
+// IsUniqueViolation returns if given error is a unique constraint violation.
+func IsUniqueViolation(err error) bool {
+	return hasErrorCode(err, UniqueViolationCode)
+}
+
+// IsForeignKeyViolation returns if given error is a foreign key constraint violation.
+func IsForeignKeyViolation(err error) bool {
+	return hasErrorCode(err, ForeignKeyViolationCode)
+}
+
+// IsNotNullViolation returns if given error is a not null constraint violation.
+func IsNotNullViolation(err error) bool {
+	return hasErrorCode(err, NotNullViolationCode)
+}
+
+// IsSerializationFailure returns if given error is a serialization failure.
+func IsSerializationFailure(err error) bool {
+	return hasErrorCode(err, SerializationFailureCode)
+}
+
+// IsDeadlock returns if given error is a deadlock.
+func IsDeadlock(err error) bool {
+	return hasErrorCode(err, DeadlockDetectedCode)
+}
+
+// IsQueryCanceled returns if given error is a query canceled, either by a timeout or by a user request.
+func IsQueryCanceled(err error) bool {
+	return hasErrorCode(err, QueryCanceledCode)
+}
+
+// UniqueViolation describes which constraint, columns and fields caused a unique constraint violation.
+type UniqueViolation struct {
+	Constraint string
+	Columns    []string
+	Fields     []Field
+}
+
+// GetUniqueViolation returns the unique constraint violation from given error.
+// If a model is given, the columns causing the violation are mapped to fields of its schema, where possible.
+func GetUniqueViolation(driver Driver, model Model, err error) (*UniqueViolation, bool) {
+	thr, ok := getPostgresError(err)
+	if !ok || string(thr.Code) != UniqueViolationCode {
+		return nil, false
+	}
+
+	violation := &UniqueViolation{
+		Constraint: thr.Constraint,
+		Columns:    getErrorDetailColumns(thr.Detail),
+		Fields:     []Field{},
+	}
+
+	if driver == nil || model == nil {
+		return violation, true
+	}
+
+	schema, err := GetSchema(driver, model)
+	if err != nil {
+		return violation, true
+	}
+
+	for _, column := range violation.Columns {
+		field, ok := schema.fields[column]
+		if ok {
+			violation.Fields = append(violation.Fields, field)
+		}
+	}
+
+	return violation, true
+}
+
+// getPostgresError returns the PostgreSQL error from given error, if any.
+func getPostgresError(err error) (*pq.Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+	thr, ok := errors.Cause(err).(*pq.Error)
+	return thr, ok
+}
+
+// hasErrorCode returns if given error is a PostgreSQL error with given code.
+func hasErrorCode(err error, code string) bool {
+	thr, ok := getPostgresError(err)
+	return ok && string(thr.Code) == code
+}
+
+// getErrorDetailColumns returns the columns from a PostgreSQL error detail,
+// such as: Key (email)=(bob@example.com) already exists.
+func getErrorDetailColumns(detail string) []string {
+	start := strings.Index(detail, "Key (")
+	if start == -1 {
+		return []string{}
+	}
+
+	detail = detail[start+len("Key ("):]
+	end := strings.Index(detail, ")=(")
+	if end == -1 {
+		return []string{}
+	}
+
+	columns := []string{}
+	for _, column := range strings.Split(detail[:end], ",") {
+		column = strings.Trim(strings.TrimSpace(column), `"`)
+		if column != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// execQuery executes given query, and reports it to the metrics registry.
+func execQuery(ctx context.Context, driver Driver, query string, args []interface{}, dest ...interface{}) error {
+	start := time.Now()
+
+	err := exec(ctx, driver, query, args, dest...)
+	observeQueryMetrics(ctx, driver, time.Since(start), err)
+	if err != nil {
+		return errors.Wrap(err, "makroud: cannot execute query")
+	}
+
+	return nil
+}
+
 func exec(ctx context.Context, driver Driver, query string, args []interface{}, dest ...interface{}) error {
 	if len(dest) > 0 {
 		if !reflectx.IsPointer(dest[0]) {
@@ -151,15 +342,22 @@ func execRowsOnModel(ctx context.Context, driver Driver, query string,
 
 	base := reflectx.GetIndirectSliceType(dest)
 	list := reflectx.GetIndirectValue(dest)
+	lenient := isLenientScan(ctx, driver)
+	first := true
 
 	for rows.Next() {
 		model := reflectx.NewValue(base).(Model)
 
-		err := schema.ScanRows(rows, model)
+		discarded, err := schema.scanRows(driver, rows, model, lenient)
 		if err != nil {
 			return err
 		}
 
+		if first {
+			logDiscardedColumns(ctx, driver, schema.ModelName(), discarded)
+			first = false
+		}
+
 		reflectx.AppendReflectSlice(list, model)
 	}
 
@@ -185,15 +383,22 @@ func execRowsOnSchemaless(ctx context.Context, driver Driver,
 
 	base := reflectx.GetIndirectSliceType(dest)
 	list := reflectx.GetIndirectValue(dest)
+	lenient := isLenientScan(ctx, driver)
+	first := true
 
 	for rows.Next() {
 		val := reflectx.NewValue(base)
 
-		err := schemaless.ScanRows(rows, val)
+		discarded, err := schemaless.scanRows(rows, val, lenient)
 		if err != nil {
 			return err
 		}
 
+		if first {
+			logDiscardedColumns(ctx, driver, schemaless.Name(), discarded)
+			first = false
+		}
+
 		reflectx.AppendReflectSlice(list, val)
 	}
 
@@ -265,7 +470,13 @@ func execRowOnModel(ctx context.Context, driver Driver, query string,
 		return err
 	}
 
-	return schema.ScanRow(row, model)
+	discarded, err := schema.scanRow(driver, row, model, isLenientScan(ctx, driver))
+	if err != nil {
+		return err
+	}
+
+	logDiscardedColumns(ctx, driver, schema.ModelName(), discarded)
+	return nil
 }
 
 func execRowOnSchemaless(ctx context.Context, driver Driver, query string,
@@ -281,7 +492,13 @@ func execRowOnSchemaless(ctx context.Context, driver Driver, query string,
 		return err
 	}
 
-	return schemaless.ScanRow(row, dest)
+	discarded, err := schemaless.scanRow(row, dest, isLenientScan(ctx, driver))
+	if err != nil {
+		return err
+	}
+
+	logDiscardedColumns(ctx, driver, schemaless.Name(), discarded)
+	return nil
 }
 
 func execRowOnScannable(ctx context.Context, driver Driver, query string,
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/ulule/loukoum/v3"
@@ -1345,3 +1346,121 @@ func TestExec_FetchPartial(t *testing.T) {
 		}
 	})
 }
+
+func TestErrors_Classification(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		cat := &Cat{Name: "Tommy"}
+		err := makroud.Save(ctx, driver, cat)
+		is.NoError(err)
+
+		err = driver.Exec(ctx, `INSERT INTO ztp_cat (id, name) VALUES ($1, $2)`, cat.ID, "Tomas")
+		is.Error(err)
+		is.True(makroud.IsUniqueViolation(err))
+		is.False(makroud.IsForeignKeyViolation(err))
+		is.False(makroud.IsNotNullViolation(err))
+
+		violation, ok := makroud.GetUniqueViolation(driver, &Cat{}, err)
+		is.True(ok)
+		is.NotNil(violation)
+		is.Equal("ztp_cat_pkey", violation.Constraint)
+		is.Equal([]string{"id"}, violation.Columns)
+		is.Len(violation.Fields, 1)
+		is.Equal("ID", violation.Fields[0].FieldName())
+
+		violation, ok = makroud.GetUniqueViolation(nil, nil, err)
+		is.True(ok)
+		is.Equal("ztp_cat_pkey", violation.Constraint)
+		is.Empty(violation.Fields)
+
+		err = driver.Exec(ctx, `INSERT INTO ztp_human (id, name, cat_id) VALUES ($1, $2, $3)`,
+			"01E0XGD1AJ6S6XDQAN6JZGKB7K", "Jasper", "01E0XGD1AJ6S6XDQAN6JZGKB7K")
+		is.Error(err)
+		is.True(makroud.IsForeignKeyViolation(err))
+		is.False(makroud.IsUniqueViolation(err))
+
+		violation, ok = makroud.GetUniqueViolation(driver, &Human{}, err)
+		is.False(ok)
+		is.Nil(violation)
+
+		err = driver.Exec(ctx, `INSERT INTO ztp_human (id, name) VALUES ($1, NULL)`, "01E0XGD1AJ6S6XDQAN6JZGKB7K")
+		is.Error(err)
+		is.True(makroud.IsNotNullViolation(err))
+
+		is.True(makroud.IsSerializationFailure(errors.WithStack(&pq.Error{Code: "40001"})))
+		is.True(makroud.IsDeadlock(errors.WithStack(&pq.Error{Code: "40P01"})))
+		is.True(makroud.IsQueryCanceled(errors.WithStack(&pq.Error{Code: "57014"})))
+		is.False(makroud.IsQueryCanceled(nil))
+		is.False(makroud.IsDeadlock(errors.New("deadlock")))
+	})
+}
+
+func TestExec_SlowQuery(t *testing.T) {
+	observer := &testObserver{}
+	options := []makroud.Option{
+		makroud.WithObserver(observer),
+		makroud.SlowQueryThreshold(20 * time.Millisecond),
+		makroud.ExplainSlowQueries(),
+	}
+	Setup(t, options...)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		is.Equal(
+			makroud.GetQueryFingerprint(`SELECT pg_sleep(0.05) WHERE 'a' = 'b'`),
+			makroud.GetQueryFingerprint(`SELECT  pg_sleep(0.1)  WHERE 'c' = 'd'`),
+		)
+		is.NotEqual(
+			makroud.GetQueryFingerprint(`SELECT pg_sleep(0.05)`),
+			makroud.GetQueryFingerprint(`SELECT pg_sleep(0.05), true`),
+		)
+
+		err := makroud.RawExec(ctx, driver, `SELECT pg_sleep(0.05)`)
+		is.NoError(err)
+
+		err = makroud.RawExec(ctx, driver, `SELECT pg_sleep(0.06)`)
+		is.NoError(err)
+
+		fingerprint := makroud.GetQueryFingerprint(`SELECT pg_sleep(0)`)
+		slow := func() []observerEvent {
+			events := []observerEvent{}
+			for _, event := range observer.Events("OnSlowQuery") {
+				if event.flags["fingerprint"] == fingerprint {
+					events = append(events, event)
+				}
+			}
+			return events
+		}
+
+		events := slow()
+		is.Len(events, 2)
+		is.True(events[0].duration >= 50*time.Millisecond)
+		is.Equal(`SELECT pg_sleep(0.05)`, events[0].flags["query"])
+		is.Contains(events[0].flags["plan"], `"Node Type"`)
+		is.NotContains(events[0].flags, "error")
+		is.Equal(`SELECT pg_sleep(0.06)`, events[1].flags["query"])
+		is.NotContains(events[1].flags, "plan")
+
+		timeout, cancel := context.WithTimeout(ctx, 30*time.Millisecond)
+		defer cancel()
+
+		err = makroud.RawExec(timeout, driver, `SELECT pg_sleep(0.5)`)
+		is.Error(err)
+
+		events = slow()
+		is.Len(events, 3)
+		is.Equal(`SELECT pg_sleep(0.5)`, events[2].flags["query"])
+		is.NotEmpty(events[2].flags["error"])
+
+		err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+			return makroud.RawExec(ctx, tx, `SELECT pg_sleep(0.07)`)
+		})
+		is.NoError(err)
+
+		events = slow()
+		is.Len(events, 4)
+		is.Equal(`SELECT pg_sleep(0.07)`, events[3].flags["query"])
+	})
+}
@@ -5,6 +5,8 @@ package hooks
 import (
 	"context"
 	"database/sql/driver"
+	"io"
+	"reflect"
 )
 
 // Wrap returns a new Connector wrapping c.
@@ -21,6 +23,17 @@ type Connector struct {
 	BeforeQuery func(ctx context.Context, query string, args []driver.NamedValue) context.Context
 	AfterQuery  func(ctx context.Context, rows driver.Rows, err error)
 
+	BeforePrepare func(ctx context.Context, query string) context.Context
+	AfterPrepare  func(ctx context.Context, stmt driver.Stmt, err error)
+
+	BeforeStmtExec func(ctx context.Context, query string, args []driver.NamedValue) context.Context
+	AfterStmtExec  func(ctx context.Context, result driver.Result, err error)
+
+	BeforeStmtQuery func(ctx context.Context, query string, args []driver.NamedValue) context.Context
+	AfterStmtQuery  func(ctx context.Context, rows driver.Rows, err error)
+
+	AfterRowsClose func(ctx context.Context, count int, err error)
+
 	BeforeBegin func(ctx context.Context, opts driver.TxOptions) context.Context
 	AfterBegin  func(ctx context.Context, tx driver.Tx, err error)
 
@@ -42,37 +55,51 @@ func (connector *Connector) Connect(ctx context.Context) (driver.Conn, error) {
 	if connector.AfterConnect != nil {
 		connector.AfterConnect(ctx, c, err)
 	}
-	return &conn{
-		wrapped:        c,
-		BeforeExec:     connector.BeforeExec,
-		AfterExec:      connector.AfterExec,
-		BeforeQuery:    connector.BeforeQuery,
-		AfterQuery:     connector.AfterQuery,
-		BeforeBegin:    connector.BeforeBegin,
-		AfterBegin:     connector.AfterBegin,
-		BeforeCommit:   connector.BeforeCommit,
-		AfterCommit:    connector.AfterCommit,
-		BeforeRollback: connector.BeforeRollback,
-		AfterRollback:  connector.AfterRollback,
-	}, err
+	if err != nil {
+		return nil, err
+	}
+	return connector.wrapConn(c), nil
 }
 
 // Driver implements database/sql/driver.Connector.
 func (connector *Connector) Driver() driver.Driver { return connector.wrapped.Driver() }
 
+// wrapRows wraps given rows if an AfterRowsClose hook is defined.
+func (connector *Connector) wrapRows(ctx context.Context, r driver.Rows) driver.Rows {
+	if r == nil || connector.AfterRowsClose == nil {
+		return r
+	}
+	return &rows{
+		wrapped:   r,
+		ctx:       ctx,
+		connector: connector,
+	}
+}
+
+// wrapConn wraps given conn. It only implements driver.Pinger and driver.SessionResetter if the wrapped
+// conn does, so database/sql keeps its default behavior otherwise.
+func (connector *Connector) wrapConn(c driver.Conn) driver.Conn {
+	wrapped := &conn{
+		wrapped:   c,
+		connector: connector,
+	}
+	_, isPinger := c.(driver.Pinger)
+	_, isResetter := c.(driver.SessionResetter)
+	switch {
+	case isPinger && isResetter:
+		return &pingerResetterConn{wrapped}
+	case isPinger:
+		return &pingerConn{wrapped}
+	case isResetter:
+		return &resetterConn{wrapped}
+	default:
+		return wrapped
+	}
+}
+
 type conn struct {
-	wrapped driver.Conn
-
-	BeforeExec     func(ctx context.Context, query string, args []driver.NamedValue) context.Context
-	AfterExec      func(ctx context.Context, result driver.Result, err error)
-	BeforeQuery    func(ctx context.Context, query string, args []driver.NamedValue) context.Context
-	AfterQuery     func(ctx context.Context, rows driver.Rows, err error)
-	BeforeBegin    func(ctx context.Context, opts driver.TxOptions) context.Context
-	AfterBegin     func(ctx context.Context, tx driver.Tx, err error)
-	BeforeCommit   func(ctx context.Context) context.Context
-	AfterCommit    func(ctx context.Context, err error)
-	BeforeRollback func(ctx context.Context) context.Context
-	AfterRollback  func(ctx context.Context, err error)
+	wrapped   driver.Conn
+	connector *Connector
 }
 
 func (c *conn) Begin() (driver.Tx, error) {
@@ -85,85 +112,339 @@ func (c *conn) Close() error {
 }
 
 func (c *conn) Prepare(query string) (driver.Stmt, error) {
-	return c.wrapped.Prepare(query)
+	return c.PrepareContext(context.Background(), query)
 }
 
 var (
-	_ driver.ExecerContext  = &conn{}
-	_ driver.QueryerContext = &conn{}
-	_ driver.ConnBeginTx    = &conn{}
+	_ driver.ExecerContext      = &conn{}
+	_ driver.QueryerContext     = &conn{}
+	_ driver.ConnPrepareContext = &conn{}
+	_ driver.ConnBeginTx        = &conn{}
+	_ driver.NamedValueChecker  = &conn{}
+	_ driver.Pinger             = &pingerConn{}
+	_ driver.SessionResetter    = &resetterConn{}
+	_ driver.Pinger             = &pingerResetterConn{}
+	_ driver.SessionResetter    = &pingerResetterConn{}
 )
 
 func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	if c.BeforeExec != nil {
-		ctx = c.BeforeExec(ctx, query, args)
+	execer, ok := c.wrapped.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if c.connector.BeforeExec != nil {
+		ctx = c.connector.BeforeExec(ctx, query, args)
 	}
-	result, err := c.wrapped.(driver.ExecerContext).ExecContext(ctx, query, args)
-	if c.AfterExec != nil {
-		c.AfterExec(ctx, result, err)
+	result, err := execer.ExecContext(ctx, query, args)
+	if c.connector.AfterExec != nil {
+		c.connector.AfterExec(ctx, result, err)
 	}
 	return result, err
 }
 
 func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
-	if c.BeforeQuery != nil {
-		ctx = c.BeforeQuery(ctx, query, args)
+	queryer, ok := c.wrapped.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if c.connector.BeforeQuery != nil {
+		ctx = c.connector.BeforeQuery(ctx, query, args)
+	}
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if c.connector.AfterQuery != nil {
+		c.connector.AfterQuery(ctx, rows, err)
+	}
+	return c.connector.wrapRows(ctx, rows), err
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if c.connector.BeforePrepare != nil {
+		ctx = c.connector.BeforePrepare(ctx, query)
 	}
-	rows, err := c.wrapped.(driver.QueryerContext).QueryContext(ctx, query, args)
-	if c.AfterQuery != nil {
-		c.AfterQuery(ctx, rows, err)
+	var (
+		s   driver.Stmt
+		err error
+	)
+	preparer, ok := c.wrapped.(driver.ConnPrepareContext)
+	if ok {
+		s, err = preparer.PrepareContext(ctx, query)
+	} else {
+		s, err = c.wrapped.Prepare(query)
 	}
-	return rows, err
+	if c.connector.AfterPrepare != nil {
+		c.connector.AfterPrepare(ctx, s, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{
+		wrapped:   s,
+		query:     query,
+		connector: c.connector,
+	}, nil
 }
 
 func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
-	if c.BeforeBegin != nil {
-		ctx = c.BeforeBegin(ctx, opts)
+	if c.connector.BeforeBegin != nil {
+		ctx = c.connector.BeforeBegin(ctx, opts)
 	}
 	t, err := c.wrapped.(driver.ConnBeginTx).BeginTx(ctx, opts)
-	if c.AfterBegin != nil {
-		c.AfterBegin(ctx, t, err)
+	if c.connector.AfterBegin != nil {
+		c.connector.AfterBegin(ctx, t, err)
 	}
 	return &tx{
-		wrapped:        t,
-		ctx:            ctx,
-		BeforeCommit:   c.BeforeCommit,
-		AfterCommit:    c.AfterCommit,
-		BeforeRollback: c.BeforeRollback,
-		AfterRollback:  c.AfterRollback,
+		wrapped:   t,
+		ctx:       ctx,
+		connector: c.connector,
 	}, err
 }
 
-type tx struct {
-	wrapped driver.Tx
-	ctx     context.Context
+func (c *conn) ping(ctx context.Context) error {
+	return c.wrapped.(driver.Pinger).Ping(ctx)
+}
 
-	BeforeCommit   func(ctx context.Context) context.Context
-	AfterCommit    func(ctx context.Context, err error)
-	BeforeRollback func(ctx context.Context) context.Context
-	AfterRollback  func(ctx context.Context, err error)
+func (c *conn) resetSession(ctx context.Context) error {
+	return c.wrapped.(driver.SessionResetter).ResetSession(ctx)
+}
+
+type pingerConn struct {
+	*conn
+}
+
+func (c *pingerConn) Ping(ctx context.Context) error {
+	return c.ping(ctx)
+}
+
+type resetterConn struct {
+	*conn
+}
+
+func (c *resetterConn) ResetSession(ctx context.Context) error {
+	return c.resetSession(ctx)
+}
+
+type pingerResetterConn struct {
+	*conn
+}
+
+func (c *pingerResetterConn) Ping(ctx context.Context) error {
+	return c.ping(ctx)
+}
+
+func (c *pingerResetterConn) ResetSession(ctx context.Context) error {
+	return c.resetSession(ctx)
+}
+
+func (c *conn) CheckNamedValue(value *driver.NamedValue) error {
+	checker, ok := c.wrapped.(driver.NamedValueChecker)
+	if !ok {
+		return driver.ErrSkip
+	}
+	return checker.CheckNamedValue(value)
+}
+
+type stmt struct {
+	wrapped   driver.Stmt
+	query     string
+	connector *Connector
+}
+
+var (
+	_ driver.StmtExecContext   = &stmt{}
+	_ driver.StmtQueryContext  = &stmt{}
+	_ driver.NamedValueChecker = &stmt{}
+)
+
+func (s *stmt) Close() error {
+	return s.wrapped.Close()
+}
+
+func (s *stmt) NumInput() int {
+	return s.wrapped.NumInput()
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	// nolint:staticcheck
+	return s.wrapped.Exec(args)
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	// nolint:staticcheck
+	return s.wrapped.Query(args)
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if s.connector.BeforeStmtExec != nil {
+		ctx = s.connector.BeforeStmtExec(ctx, s.query, args)
+	}
+	var (
+		result driver.Result
+		err    error
+	)
+	execer, ok := s.wrapped.(driver.StmtExecContext)
+	if ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		// nolint:staticcheck
+		result, err = s.wrapped.Exec(toValues(args))
+	}
+	if s.connector.AfterStmtExec != nil {
+		s.connector.AfterStmtExec(ctx, result, err)
+	}
+	return result, err
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if s.connector.BeforeStmtQuery != nil {
+		ctx = s.connector.BeforeStmtQuery(ctx, s.query, args)
+	}
+	var (
+		rows driver.Rows
+		err  error
+	)
+	queryer, ok := s.wrapped.(driver.StmtQueryContext)
+	if ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		// nolint:staticcheck
+		rows, err = s.wrapped.Query(toValues(args))
+	}
+	if s.connector.AfterStmtQuery != nil {
+		s.connector.AfterStmtQuery(ctx, rows, err)
+	}
+	return s.connector.wrapRows(ctx, rows), err
+}
+
+func (s *stmt) CheckNamedValue(value *driver.NamedValue) error {
+	checker, ok := s.wrapped.(driver.NamedValueChecker)
+	if !ok {
+		return driver.ErrSkip
+	}
+	return checker.CheckNamedValue(value)
+}
+
+// toValues converts named values to values, for drivers without context support.
+func toValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i := range args {
+		values[i] = args[i].Value
+	}
+	return values
+}
+
+type rows struct {
+	wrapped   driver.Rows
+	ctx       context.Context
+	connector *Connector
+	count     int
+}
+
+var (
+	_ driver.RowsNextResultSet              = &rows{}
+	_ driver.RowsColumnTypeScanType         = &rows{}
+	_ driver.RowsColumnTypeDatabaseTypeName = &rows{}
+	_ driver.RowsColumnTypeLength           = &rows{}
+	_ driver.RowsColumnTypeNullable         = &rows{}
+	_ driver.RowsColumnTypePrecisionScale   = &rows{}
+)
+
+func (r *rows) Columns() []string {
+	return r.wrapped.Columns()
+}
+
+func (r *rows) Close() error {
+	err := r.wrapped.Close()
+	r.connector.AfterRowsClose(r.ctx, r.count, err)
+	return err
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	err := r.wrapped.Next(dest)
+	if err == nil {
+		r.count++
+	}
+	return err
+}
+
+func (r *rows) HasNextResultSet() bool {
+	wrapped, ok := r.wrapped.(driver.RowsNextResultSet)
+	return ok && wrapped.HasNextResultSet()
+}
+
+func (r *rows) NextResultSet() error {
+	wrapped, ok := r.wrapped.(driver.RowsNextResultSet)
+	if !ok {
+		return io.EOF
+	}
+	return wrapped.NextResultSet()
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	wrapped, ok := r.wrapped.(driver.RowsColumnTypeScanType)
+	if !ok {
+		return reflect.TypeOf(new(interface{})).Elem()
+	}
+	return wrapped.ColumnTypeScanType(index)
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	wrapped, ok := r.wrapped.(driver.RowsColumnTypeDatabaseTypeName)
+	if !ok {
+		return ""
+	}
+	return wrapped.ColumnTypeDatabaseTypeName(index)
+}
+
+func (r *rows) ColumnTypeLength(index int) (int64, bool) {
+	wrapped, ok := r.wrapped.(driver.RowsColumnTypeLength)
+	if !ok {
+		return 0, false
+	}
+	return wrapped.ColumnTypeLength(index)
+}
+
+func (r *rows) ColumnTypeNullable(index int) (bool, bool) {
+	wrapped, ok := r.wrapped.(driver.RowsColumnTypeNullable)
+	if !ok {
+		return false, false
+	}
+	return wrapped.ColumnTypeNullable(index)
+}
+
+func (r *rows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	wrapped, ok := r.wrapped.(driver.RowsColumnTypePrecisionScale)
+	if !ok {
+		return 0, 0, false
+	}
+	return wrapped.ColumnTypePrecisionScale(index)
+}
+
+type tx struct {
+	wrapped   driver.Tx
+	ctx       context.Context
+	connector *Connector
 }
 
 func (tx *tx) Commit() error {
 	ctx := tx.ctx
-	if tx.BeforeCommit != nil {
-		ctx = tx.BeforeCommit(ctx)
+	if tx.connector.BeforeCommit != nil {
+		ctx = tx.connector.BeforeCommit(ctx)
 	}
 	err := tx.wrapped.Commit()
-	if tx.AfterCommit != nil {
-		tx.AfterCommit(ctx, err)
+	if tx.connector.AfterCommit != nil {
+		tx.connector.AfterCommit(ctx, err)
 	}
 	return err
 }
 
 func (tx *tx) Rollback() error {
 	ctx := tx.ctx
-	if tx.BeforeRollback != nil {
-		ctx = tx.BeforeRollback(ctx)
+	if tx.connector.BeforeRollback != nil {
+		ctx = tx.connector.BeforeRollback(ctx)
 	}
 	err := tx.wrapped.Rollback()
-	if tx.AfterRollback != nil {
-		tx.AfterRollback(ctx, err)
+	if tx.connector.AfterRollback != nil {
+		tx.connector.AfterRollback(ctx, err)
 	}
 	return err
 }
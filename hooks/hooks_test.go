@@ -0,0 +1,218 @@
+package hooks_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/makroud/hooks"
+)
+
+type stubConnector struct {
+	conn driver.Conn
+}
+
+func (connector stubConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return connector.conn, nil
+}
+
+func (connector stubConnector) Driver() driver.Driver {
+	return stubDriver{}
+}
+
+type stubDriver struct{}
+
+func (stubDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("stub: open is not supported")
+}
+
+type stubConn struct {
+	values [][]driver.Value
+}
+
+func (conn *stubConn) Prepare(query string) (driver.Stmt, error) {
+	return &stubStmt{values: conn.values}, nil
+}
+
+func (conn *stubConn) Close() error {
+	return nil
+}
+
+func (conn *stubConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("stub: begin is not supported")
+}
+
+type stubStmt struct {
+	values [][]driver.Value
+}
+
+func (stmt *stubStmt) Close() error {
+	return nil
+}
+
+func (stmt *stubStmt) NumInput() int {
+	return -1
+}
+
+func (stmt *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (stmt *stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &stubRows{values: stmt.values}, nil
+}
+
+type stubRows struct {
+	values [][]driver.Value
+	index  int
+}
+
+func (rows *stubRows) Columns() []string {
+	return []string{"name"}
+}
+
+func (rows *stubRows) Close() error {
+	return nil
+}
+
+func (rows *stubRows) Next(dest []driver.Value) error {
+	if rows.index >= len(rows.values) {
+		return io.EOF
+	}
+	copy(dest, rows.values[rows.index])
+	rows.index++
+	return nil
+}
+
+var (
+	errPing         = errors.New("stub: ping")
+	errResetSession = errors.New("stub: reset session")
+	errCheck        = errors.New("stub: check named value")
+)
+
+type stubOptionalConn struct {
+	stubConn
+}
+
+func (conn *stubOptionalConn) Ping(ctx context.Context) error {
+	return errPing
+}
+
+func (conn *stubOptionalConn) ResetSession(ctx context.Context) error {
+	return errResetSession
+}
+
+func (conn *stubOptionalConn) CheckNamedValue(value *driver.NamedValue) error {
+	return errCheck
+}
+
+func TestHooks_Stmt(t *testing.T) {
+	ctx := context.Background()
+	is := require.New(t)
+
+	events := []string{}
+	counts := []int{}
+	queries := []string{}
+
+	connector := hooks.Wrap(stubConnector{conn: &stubConn{
+		values: [][]driver.Value{{"Ludmila"}, {"Ilse"}},
+	}})
+	connector.BeforePrepare = func(ctx context.Context, query string) context.Context {
+		events = append(events, "BeforePrepare")
+		return ctx
+	}
+	connector.AfterPrepare = func(ctx context.Context, stmt driver.Stmt, err error) {
+		events = append(events, "AfterPrepare")
+	}
+	connector.BeforeStmtExec = func(ctx context.Context, query string, args []driver.NamedValue) context.Context {
+		events = append(events, "BeforeStmtExec")
+		queries = append(queries, query)
+		return ctx
+	}
+	connector.AfterStmtExec = func(ctx context.Context, result driver.Result, err error) {
+		events = append(events, "AfterStmtExec")
+	}
+	connector.BeforeStmtQuery = func(ctx context.Context, query string, args []driver.NamedValue) context.Context {
+		events = append(events, "BeforeStmtQuery")
+		queries = append(queries, query)
+		return ctx
+	}
+	connector.AfterStmtQuery = func(ctx context.Context, rows driver.Rows, err error) {
+		events = append(events, "AfterStmtQuery")
+	}
+	connector.AfterRowsClose = func(ctx context.Context, count int, err error) {
+		events = append(events, "AfterRowsClose")
+		counts = append(counts, count)
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	stmt, err := db.PrepareContext(ctx, "UPDATE owl SET name = $1")
+	is.NoError(err)
+	_, err = stmt.ExecContext(ctx, "Ludmilla")
+	is.NoError(err)
+	is.NoError(stmt.Close())
+
+	stmt, err = db.PrepareContext(ctx, "SELECT name FROM owl")
+	is.NoError(err)
+	rows, err := stmt.QueryContext(ctx)
+	is.NoError(err)
+	names := []string{}
+	for rows.Next() {
+		name := ""
+		is.NoError(rows.Scan(&name))
+		names = append(names, name)
+	}
+	is.NoError(rows.Close())
+	is.NoError(stmt.Close())
+
+	is.Equal([]string{"Ludmila", "Ilse"}, names)
+	is.Equal([]string{
+		"BeforePrepare", "AfterPrepare", "BeforeStmtExec", "AfterStmtExec",
+		"BeforePrepare", "AfterPrepare", "BeforeStmtQuery", "AfterStmtQuery", "AfterRowsClose",
+	}, events)
+	is.Equal([]string{"UPDATE owl SET name = $1", "SELECT name FROM owl"}, queries)
+	is.Equal([]int{2}, counts)
+}
+
+func TestHooks_Passthrough(t *testing.T) {
+	ctx := context.Background()
+	is := require.New(t)
+
+	conn, err := hooks.Wrap(stubConnector{conn: &stubConn{}}).Connect(ctx)
+	is.NoError(err)
+
+	_, ok := conn.(driver.Pinger)
+	is.False(ok)
+	_, ok = conn.(driver.SessionResetter)
+	is.False(ok)
+
+	checker, ok := conn.(driver.NamedValueChecker)
+	is.True(ok)
+	is.Equal(driver.ErrSkip, checker.CheckNamedValue(&driver.NamedValue{}))
+
+	db := sql.OpenDB(hooks.Wrap(stubConnector{conn: &stubConn{}}))
+	defer db.Close()
+	is.NoError(db.PingContext(ctx))
+
+	conn, err = hooks.Wrap(stubConnector{conn: &stubOptionalConn{}}).Connect(ctx)
+	is.NoError(err)
+
+	pinger, ok := conn.(driver.Pinger)
+	is.True(ok)
+	is.Equal(errPing, pinger.Ping(ctx))
+
+	resetter, ok := conn.(driver.SessionResetter)
+	is.True(ok)
+	is.Equal(errResetSession, resetter.ResetSession(ctx))
+
+	checker, ok = conn.(driver.NamedValueChecker)
+	is.True(ok)
+	is.Equal(errCheck, checker.CheckNamedValue(&driver.NamedValue{}))
+}
@@ -0,0 +1,151 @@
+package hooks
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// List of attributes given to spans.
+const (
+	// AttributeStatement is the attribute containing the executed statement.
+	AttributeStatement = "db.statement"
+	// AttributeSystem is the attribute containing the database system.
+	AttributeSystem = "db.system"
+	// AttributeOperation is the attribute containing the high level operation, such as Save or Select.
+	AttributeOperation = "makroud.operation"
+)
+
+// List of spans names.
+const (
+	SpanQuery       = "makroud.query"
+	SpanExec        = "makroud.exec"
+	SpanPrepare     = "makroud.prepare"
+	SpanTransaction = "makroud.transaction"
+	SpanCommit      = "makroud.commit"
+	SpanRollback    = "makroud.rollback"
+)
+
+// A Tracer creates spans, it's designed to be easily adapted to OpenTelemetry.
+type Tracer interface {
+	// Start creates a span with given name and attributes, and returns a context containing this span.
+	Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span)
+}
+
+// A Span is an operation traced by a Tracer.
+type Span interface {
+	// End completes the span, with an error if the operation has failed.
+	End(err error)
+}
+
+type (
+	operationKey   struct{}
+	spanKey        struct{}
+	transactionKey struct{}
+)
+
+// WithOperation returns a copy of given context with given operation, unless it already has one.
+// This operation is given as an attribute to every span created with this context.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	_, ok := GetOperation(ctx)
+	if ok {
+		return ctx
+	}
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// GetOperation returns the operation from given context, if any.
+func GetOperation(ctx context.Context) (string, bool) {
+	operation, ok := ctx.Value(operationKey{}).(string)
+	return operation, ok
+}
+
+// Trace returns a new Connector wrapping c, which creates a span with given tracer for every query,
+// prepared statement, transaction, commit and rollback. The system is given as an attribute to every span.
+func Trace(c driver.Connector, tracer Tracer, system string) *Connector {
+	connector := Wrap(c)
+
+	start := func(ctx context.Context, key interface{}, name string, query string) context.Context {
+		attributes := map[string]string{
+			AttributeSystem: system,
+		}
+		if query != "" {
+			attributes[AttributeStatement] = query
+		}
+		operation, ok := GetOperation(ctx)
+		if ok {
+			attributes[AttributeOperation] = operation
+		}
+
+		ctx, span := tracer.Start(ctx, name, attributes)
+		return context.WithValue(ctx, key, span)
+	}
+
+	end := func(ctx context.Context, key interface{}, err error) {
+		span, ok := ctx.Value(key).(Span)
+		if ok {
+			span.End(err)
+		}
+	}
+
+	connector.BeforeExec = func(ctx context.Context, query string, args []driver.NamedValue) context.Context {
+		return start(ctx, spanKey{}, SpanExec, query)
+	}
+	connector.AfterExec = func(ctx context.Context, result driver.Result, err error) {
+		end(ctx, spanKey{}, err)
+	}
+
+	connector.BeforeQuery = func(ctx context.Context, query string, args []driver.NamedValue) context.Context {
+		return start(ctx, spanKey{}, SpanQuery, query)
+	}
+	connector.AfterQuery = func(ctx context.Context, rows driver.Rows, err error) {
+		end(ctx, spanKey{}, err)
+	}
+
+	connector.BeforePrepare = func(ctx context.Context, query string) context.Context {
+		return start(ctx, spanKey{}, SpanPrepare, query)
+	}
+	connector.AfterPrepare = func(ctx context.Context, stmt driver.Stmt, err error) {
+		end(ctx, spanKey{}, err)
+	}
+
+	connector.BeforeStmtExec = func(ctx context.Context, query string, args []driver.NamedValue) context.Context {
+		return start(ctx, spanKey{}, SpanExec, query)
+	}
+	connector.AfterStmtExec = func(ctx context.Context, result driver.Result, err error) {
+		end(ctx, spanKey{}, err)
+	}
+
+	connector.BeforeStmtQuery = func(ctx context.Context, query string, args []driver.NamedValue) context.Context {
+		return start(ctx, spanKey{}, SpanQuery, query)
+	}
+	connector.AfterStmtQuery = func(ctx context.Context, rows driver.Rows, err error) {
+		end(ctx, spanKey{}, err)
+	}
+
+	connector.BeforeBegin = func(ctx context.Context, opts driver.TxOptions) context.Context {
+		return start(ctx, transactionKey{}, SpanTransaction, "")
+	}
+	connector.AfterBegin = func(ctx context.Context, tx driver.Tx, err error) {
+		if err != nil {
+			end(ctx, transactionKey{}, err)
+		}
+	}
+
+	connector.BeforeCommit = func(ctx context.Context) context.Context {
+		return start(ctx, spanKey{}, SpanCommit, "")
+	}
+	connector.AfterCommit = func(ctx context.Context, err error) {
+		end(ctx, spanKey{}, err)
+		end(ctx, transactionKey{}, err)
+	}
+
+	connector.BeforeRollback = func(ctx context.Context) context.Context {
+		return start(ctx, spanKey{}, SpanRollback, "")
+	}
+	connector.AfterRollback = func(ctx context.Context, err error) {
+		end(ctx, spanKey{}, err)
+		end(ctx, transactionKey{}, err)
+	}
+
+	return connector
+}
@@ -0,0 +1,58 @@
+package makroud
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// jsonScanner is a sql.Scanner that unmarshals a JSON column into its destination.
+type jsonScanner struct {
+	dest interface{}
+}
+
+// Scan implements sql.Scanner interface.
+func (scanner jsonScanner) Scan(src interface{}) error {
+	value := reflect.ValueOf(scanner.dest).Elem()
+
+	// Reset destination so a previous row is never merged with this one, and so NULL gives a zero value.
+	value.Set(reflect.Zero(value.Type()))
+
+	var data []byte
+	switch cast := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = cast
+	case string:
+		data = []byte(cast)
+	default:
+		return errors.Errorf("cannot unmarshal %T as JSON", src)
+	}
+
+	return json.Unmarshal(data, scanner.dest)
+}
+
+// toJSONValue marshals given value to a JSON document.
+// A nil pointer, map or slice is stored as NULL.
+func toJSONValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		if reflected.IsNil() {
+			return nil, nil
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
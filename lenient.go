@@ -0,0 +1,41 @@
+package makroud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// lenientScanKey is the context key used to enable or disable lenient scan.
+type lenientScanKey struct{}
+
+// WithLenientScan returns a copy of given context which enables, or disables, lenient scan for queries executed
+// with it, whatever the driver configuration.
+// With lenient scan, a column without destination in a model is discarded instead of returning an error.
+func WithLenientScan(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, lenientScanKey{}, enabled)
+}
+
+// isLenientScan returns if lenient scan is enabled for given context and driver.
+func isLenientScan(ctx context.Context, driver Driver) bool {
+	enabled, ok := ctx.Value(lenientScanKey{}).(bool)
+	if ok {
+		return enabled
+	}
+	client := getClient(driver)
+	return client != nil && client.lenientScan()
+}
+
+// logDiscardedColumns reports given discarded columns to driver's logger, if it's a DebugLogger.
+func logDiscardedColumns(ctx context.Context, driver Driver, name string, columns []string) {
+	if len(columns) == 0 || !driver.HasLogger() {
+		return
+	}
+
+	logger, ok := driver.Logger().(DebugLogger)
+	if !ok {
+		return
+	}
+
+	logger.Debug(ctx, fmt.Sprintf("makroud: discarded columns %s in %s", strings.Join(columns, ", "), name))
+}
@@ -0,0 +1,134 @@
+package makroud_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/makroud"
+)
+
+type debugLogger struct {
+	mutex    sync.Mutex
+	messages []string
+}
+
+func (e *debugLogger) Log(ctx context.Context, query string, duration time.Duration) {}
+
+func (e *debugLogger) Debug(ctx context.Context, message string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.messages = append(e.messages, message)
+}
+
+func (e *debugLogger) read() []string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	messages := e.messages
+	e.messages = nil
+	return messages
+}
+
+type CatSummary struct {
+	ID   string `mk:"id"`
+	Name string `mk:"name"`
+}
+
+func TestLenient_Driver(t *testing.T) {
+	logger := &debugLogger{}
+
+	Setup(t, makroud.LenientScan(), makroud.WithLogger(logger))(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		cat1 := &CatName{Name: "Tom"}
+		err := makroud.Save(ctx, driver, cat1)
+		is.NoError(err)
+
+		cat2 := &CatName{Name: "Felix"}
+		err = makroud.Save(ctx, driver, cat2)
+		is.NoError(err)
+		logger.read()
+
+		query := `SELECT *, 'extra' AS nickname FROM ztp_cat ORDER BY name`
+
+		cat := &CatName{}
+		err = makroud.RawExec(ctx, driver, query, cat)
+		is.NoError(err)
+		is.Equal(cat2.ID, cat.ID)
+		is.Equal("Felix", cat.Name)
+		is.Equal([]string{
+			"makroud: discarded columns created_at, updated_at, deleted_at, nickname in CatName",
+		}, logger.read())
+
+		cats := []CatName{}
+		err = makroud.RawExec(ctx, driver, query, &cats)
+		is.NoError(err)
+		is.Len(cats, 2)
+		is.Equal("Felix", cats[0].Name)
+		is.Equal("Tom", cats[1].Name)
+		is.Equal([]string{
+			"makroud: discarded columns created_at, updated_at, deleted_at, nickname in CatName",
+		}, logger.read())
+
+		summary := &CatSummary{}
+		err = makroud.RawExec(ctx, driver, query, summary)
+		is.NoError(err)
+		is.Equal(cat2.ID, summary.ID)
+		is.Equal("Felix", summary.Name)
+		is.Equal([]string{
+			"makroud: discarded columns created_at, updated_at, deleted_at, nickname in CatSummary",
+		}, logger.read())
+
+		summaries := []CatSummary{}
+		err = makroud.RawExec(ctx, driver, query, &summaries)
+		is.NoError(err)
+		is.Len(summaries, 2)
+		is.Equal("Tom", summaries[1].Name)
+		is.Len(logger.read(), 1)
+
+		err = makroud.RawExec(makroud.WithLenientScan(ctx, false), driver, query, cat)
+		is.Error(err)
+		is.Equal(makroud.ErrSchemaColumnRequired, errors.Cause(err))
+		is.Empty(logger.read())
+	})
+}
+
+func TestLenient_Context(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		cat := &CatName{Name: "Sylvester"}
+		err := makroud.Save(ctx, driver, cat)
+		is.NoError(err)
+
+		query := `SELECT * FROM ztp_cat`
+
+		result := &CatName{}
+		err = makroud.RawExec(ctx, driver, query, result)
+		is.Error(err)
+		is.Equal(makroud.ErrSchemaColumnRequired, errors.Cause(err))
+
+		summaries := []CatSummary{}
+		err = makroud.RawExec(ctx, driver, query, &summaries)
+		is.Error(err)
+		is.Equal(makroud.ErrSchemaColumnRequired, errors.Cause(err))
+
+		result = &CatName{}
+		err = makroud.RawExec(makroud.WithLenientScan(ctx, true), driver, query, result)
+		is.NoError(err)
+		is.Equal(cat.ID, result.ID)
+		is.Equal("Sylvester", result.Name)
+
+		summaries = []CatSummary{}
+		err = makroud.RawExec(makroud.WithLenientScan(ctx, true), driver, query, &summaries)
+		is.NoError(err)
+		is.Len(summaries, 1)
+		is.Equal("Sylvester", summaries[0].Name)
+	})
+}
@@ -2,6 +2,7 @@ package makroud
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -11,6 +12,41 @@ type Logger interface {
 	Log(ctx context.Context, query string, duration time.Duration)
 }
 
+// EventLogger is an optional Logger that also collect a structured event for every query executed by a Client.
+type EventLogger interface {
+	Logger
+	// LogEvent push what query was executed, with its arguments and its outcome.
+	LogEvent(ctx context.Context, event QueryEvent)
+}
+
+// DebugLogger is an optional Logger that also collect debug messages, such as columns discarded by a lenient scan.
+type DebugLogger interface {
+	Logger
+	// Debug push a debug message.
+	Debug(ctx context.Context, message string)
+}
+
+// RedactedArg is used in QueryEvent instead of arguments values, if redaction is enabled.
+const RedactedArg = "[REDACTED]"
+
+// QueryEvent describes a query executed by a Client.
+type QueryEvent struct {
+	// Query is the parameterised query.
+	Query string
+	// Args are the query arguments, or RedactedArg if redaction is enabled.
+	Args []interface{}
+	// Rows is the number of rows affected, for an execution, or returned, for a query.
+	Rows int64
+	// Err is the error returned by the query, if any.
+	Err error
+	// Duration is the time elapsed until the query was done, including the rows iteration.
+	Duration time.Duration
+	// DriverName is the driver name used by the Client.
+	DriverName string
+	// Transaction defines if the query was executed inside a transaction.
+	Transaction bool
+}
+
 // Log will emmit given query on driver's attached Logger.
 // nolint: interfacer
 func Log(ctx context.Context, driver Driver, query Query, duration time.Duration) {
@@ -19,3 +55,80 @@ func Log(ctx context.Context, driver Driver, query Query, duration time.Duration
 	}
 	driver.Logger().Log(ctx, query.String(), duration)
 }
+
+// queryTracker collects a QueryEvent while a query is executed, and delivers it once done.
+// Once done, the query is also reported to the client observer if it's a slow query.
+type queryTracker struct {
+	ctx    context.Context
+	client *Client
+	logger EventLogger
+	args   []interface{}
+	event  QueryEvent
+	start  time.Time
+	once   sync.Once
+}
+
+// newQueryTracker creates a new queryTracker if given client has an EventLogger or reports slow queries,
+// or returns nil otherwise.
+func newQueryTracker(ctx context.Context, client *Client, query string, args []interface{}) *queryTracker {
+	logger, ok := client.log.(EventLogger)
+	_, slow := getSlowQueryObserver(client)
+	if !ok && !slow {
+		return nil
+	}
+
+	tracker := &queryTracker{
+		ctx:    ctx,
+		client: client,
+		logger: logger,
+		args:   args,
+		start:  time.Now(),
+		event: QueryEvent{
+			Query:       query,
+			Args:        args,
+			DriverName:  client.DriverName(),
+			Transaction: client.node.Tx() != nil,
+		},
+	}
+
+	if client.rdc {
+		redacted := make([]interface{}, len(args))
+		for i := range redacted {
+			redacted[i] = RedactedArg
+		}
+		tracker.event.Args = redacted
+	}
+
+	return tracker
+}
+
+// row increments the number of rows returned.
+func (tracker *queryTracker) row() {
+	if tracker == nil {
+		return
+	}
+	tracker.event.Rows++
+}
+
+// affected defines the number of rows affected.
+func (tracker *queryTracker) affected(rows int64) {
+	if tracker == nil {
+		return
+	}
+	tracker.event.Rows = rows
+}
+
+// done delivers the QueryEvent with given error, and reports a slow query, only once.
+func (tracker *queryTracker) done(err error) {
+	if tracker == nil {
+		return
+	}
+	tracker.once.Do(func() {
+		tracker.event.Err = err
+		tracker.event.Duration = time.Since(tracker.start)
+		if tracker.logger != nil {
+			tracker.logger.LogEvent(tracker.ctx, tracker.event)
+		}
+		observeSlowQuery(tracker.ctx, tracker.client, tracker.event.Query, tracker.args, tracker.event.Duration, err)
+	})
+}
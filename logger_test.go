@@ -78,3 +78,105 @@ func TestLogger(t *testing.T) {
 
 	})
 }
+
+type eventLogger struct {
+	logger
+	events chan makroud.QueryEvent
+}
+
+func (e *eventLogger) LogEvent(ctx context.Context, event makroud.QueryEvent) {
+	e.events <- event
+}
+
+func (e *eventLogger) readEvent() (makroud.QueryEvent, error) {
+	select {
+	case event := <-e.events:
+		return event, nil
+	case <-time.After(500 * time.Millisecond):
+		return makroud.QueryEvent{}, ErrLogTimeout
+	}
+}
+
+func (e *eventLogger) drain() {
+	for {
+		select {
+		case <-e.events:
+		default:
+			return
+		}
+	}
+}
+
+func TestLogger_Event(t *testing.T) {
+	logger := &eventLogger{
+		logger: logger{logs: make(chan string, 100)},
+		events: make(chan makroud.QueryEvent, 10),
+	}
+	Setup(t, makroud.WithLogger(logger))(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+		logger.drain()
+
+		owl := &Owl{
+			Name:         "Guacamowle",
+			FeatherColor: "lavender",
+			FavoriteFood: "Shrimps",
+		}
+
+		err := makroud.Save(ctx, driver, owl)
+		is.NoError(err)
+
+		event, err := logger.readEvent()
+		is.NoError(err)
+		is.Equal(fmt.Sprint(
+			`INSERT INTO ztp_owl (favorite_food, feather_color, group_id, name) VALUES `,
+			`($1, $2, NULL, $3) RETURNING id`,
+		), event.Query)
+		is.Equal([]interface{}{"Shrimps", "lavender", "Guacamowle"}, event.Args)
+		is.Equal(int64(1), event.Rows)
+		is.Equal(makroud.ClientDriver, event.DriverName)
+		is.False(event.Transaction)
+		is.NoError(event.Err)
+
+		owls := []Owl{}
+		err = makroud.Select(ctx, driver, &owls)
+		is.NoError(err)
+		is.Len(owls, 1)
+
+		event, err = logger.readEvent()
+		is.NoError(err)
+		is.Equal(int64(1), event.Rows)
+
+		err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+			return tx.Exec(ctx, `UPDATE ztp_owl SET name = $1`, "Nibbles")
+		})
+		is.NoError(err)
+
+		event, err = logger.readEvent()
+		is.NoError(err)
+		is.Equal(`UPDATE ztp_owl SET name = $1`, event.Query)
+		is.Equal(int64(1), event.Rows)
+		is.True(event.Transaction)
+
+		err = driver.Exec(ctx, `UPDATE ztp_owl SAT name = $1`, "Nibbles")
+		is.Error(err)
+
+		event, err = logger.readEvent()
+		is.NoError(err)
+		is.Error(event.Err)
+	})
+
+	Setup(t, makroud.WithLogger(logger), makroud.RedactArgs())(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+		logger.drain()
+
+		err := driver.Exec(ctx, `UPDATE ztp_owl SET name = $1 WHERE id = $2`, "Nibbles", 1)
+		is.NoError(err)
+
+		event, err := logger.readEvent()
+		is.NoError(err)
+		is.Equal([]interface{}{makroud.RedactedArg, makroud.RedactedArg}, event.Args)
+		is.Equal(int64(0), event.Rows)
+	})
+}
@@ -3,14 +3,19 @@ package makroud_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
+	"github.com/ulule/loukoum/v3"
+	"github.com/ulule/loukoum/v3/builder"
 
 	"github.com/ulule/makroud"
 )
@@ -3207,6 +3212,18 @@ func (Group) TableName() string {
 	return "ztp_group"
 }
 
+type Flock struct {
+	// Columns
+	ID   int64  `makroud:"column:id,pk"`
+	Name string `makroud:"column:name"`
+	// Relationships
+	Owls []ShrimpOwl
+}
+
+func (Flock) TableName() string {
+	return "ztp_group"
+}
+
 type Center struct {
 	// Columns
 	ID   string `makroud:"column:id"`
@@ -3235,6 +3252,22 @@ func (Owl) TableName() string {
 	return "ztp_owl"
 }
 
+type ShrimpOwl struct {
+	// Columns
+	ID           int64         `makroud:"column:id,pk"`
+	Name         string        `makroud:"column:name"`
+	FavoriteFood string        `makroud:"column:favorite_food"`
+	GroupID      sql.NullInt64 `makroud:"column:group_id,fk:ztp_group"`
+}
+
+func (ShrimpOwl) TableName() string {
+	return "ztp_owl"
+}
+
+func (ShrimpOwl) DefaultScope(query builder.Select) builder.Select {
+	return query.Where(loukoum.Condition("favorite_food").Equal("Shrimps"))
+}
+
 type Bag struct {
 	// Columns
 	ID    int64  `makroud:"column:id,pk"`
@@ -3248,6 +3281,18 @@ func (Bag) TableName() string {
 	return "ztp_bag"
 }
 
+type Roost struct {
+	// Columns
+	ID        int64         `makroud:"column:id,pk"`
+	Name      string        `makroud:"column:name"`
+	GroupID   sql.NullInt64 `makroud:"column:group_id,fk:ztp_group"`
+	DeletedAt pq.NullTime   `makroud:"column:deleted_at"`
+}
+
+func (Roost) TableName() string {
+	return "ztp_roost"
+}
+
 type Package struct {
 	// Columns
 	ID            string        `makroud:"column:id"`
@@ -3264,6 +3309,142 @@ func (Package) TableName() string {
 	return "ztp_package"
 }
 
+type Timestamps struct {
+	CreatedAt time.Time   `makroud:"column:created_at,default"`
+	UpdatedAt time.Time   `makroud:"column:updated_at,default"`
+	DeletedAt pq.NullTime `makroud:"column:deleted_at"`
+}
+
+type Location struct {
+	Street string `makroud:"column:street"`
+	City   string `makroud:"column:city"`
+}
+
+type Kiosk struct {
+	// Columns
+	ID       int64             `makroud:"column:id,pk"`
+	Name     string            `makroud:"column:name"`
+	Billing  Location          `makroud:"embed,prefix:billing_"`
+	Shipping Location          `makroud:"embed,prefix:shipping_"`
+	Hours    map[string]string `makroud:"column:hours,json"`
+	Manager  *Location         `makroud:"column:manager,json"`
+	Tags     []string          `makroud:"column:tags"`
+	Sizes    []int16           `makroud:"column:sizes,array"`
+	Timestamps
+}
+
+func (Kiosk) TableName() string {
+	return "ztp_kiosk"
+}
+
+type Stall struct {
+	// Columns
+	ID        int64     `makroud:"column:id,pk"`
+	Name      string    `makroud:"column:name"`
+	CreatedAt time.Time `makroud:"column:created_at,default"`
+	Timestamps
+}
+
+func (Stall) TableName() string {
+	return "ztp_kiosk"
+}
+
+type Booth struct {
+	// Columns
+	ID       int64    `makroud:"column:id,pk"`
+	Billing  Location `makroud:"embed"`
+	Shipping Location `makroud:"embed"`
+}
+
+func (Booth) TableName() string {
+	return "ztp_kiosk"
+}
+
+type Informant struct {
+	// Columns
+	ID    int64  `makroud:"column:id,pk"`
+	Name  string `makroud:"column:name,codec:rot13"`
+	Alias string `makroud:"column:alias,codec:rot13"`
+}
+
+func (Informant) TableName() string {
+	return "ztp_informant"
+}
+
+type Burrow struct {
+	// Columns
+	ID   uuid.UUID `makroud:"column:id,pk:uuid-v4"`
+	Name string    `makroud:"column:name"`
+	// Relationships
+	Bunnies []Bunny
+}
+
+func (Burrow) TableName() string {
+	return "ztp_burrow"
+}
+
+type BunnyID int32
+
+type Bunny struct {
+	// Columns
+	ID       BunnyID   `makroud:"column:id,pk"`
+	Name     string    `makroud:"column:name"`
+	BurrowID uuid.UUID `makroud:"column:burrow_id,fk:ztp_burrow"`
+	// Relationships
+	Burrow *Burrow
+}
+
+func (Bunny) TableName() string {
+	return "ztp_bunny"
+}
+
+type AccountID struct {
+	value int64
+}
+
+func (id *AccountID) Value() (driver.Value, error) {
+	return id.value, nil
+}
+
+func (id *AccountID) Scan(src interface{}) error {
+	value, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into AccountID", src)
+	}
+	id.value = value
+	return nil
+}
+
+type Account struct {
+	// Columns
+	ID   AccountID `makroud:"column:id,pk"`
+	Name string    `makroud:"column:name"`
+}
+
+func (Account) TableName() string {
+	return "ztp_account"
+}
+
+type Ticket struct {
+	// Columns
+	ID        string `makroud:"column:id,pk:ticket"`
+	Violation string `makroud:"column:violation"`
+}
+
+func (Ticket) TableName() string {
+	return "ztp_ticket"
+}
+
+type Fine struct {
+	// Columns
+	ID     int64 `makroud:"column:id,pk:snowflake"`
+	Amount int64 `makroud:"column:amount"`
+}
+
+func (Fine) TableName() string {
+	return "ztp_fine"
+}
+
 type Cat struct {
 	// Columns
 	ID        string      `makroud:"column:id,pk:ulid"`
@@ -3280,6 +3461,16 @@ func (Cat) TableName() string {
 	return "ztp_cat"
 }
 
+type CatName struct {
+	// Columns
+	ID   string `makroud:"column:id,pk:ulid"`
+	Name string `makroud:"column:name"`
+}
+
+func (CatName) TableName() string {
+	return "ztp_cat"
+}
+
 type Meow struct {
 	// Columns
 	Hash      string      `makroud:"column:hash,pk:ulid"`
@@ -3323,6 +3514,66 @@ func (Human) TableName() string {
 	return "ztp_human"
 }
 
+// ----------------------------------------------------------------------------
+// Observer
+// ----------------------------------------------------------------------------
+
+type observerEvent struct {
+	method   string
+	err      error
+	duration time.Duration
+	flags    map[string]string
+}
+
+type testObserver struct {
+	mutex  sync.Mutex
+	events []observerEvent
+}
+
+func (o *testObserver) OnClose(err error, flags map[string]string) {
+	o.record("OnClose", err, flags)
+}
+
+func (o *testObserver) OnRollback(err error, flags map[string]string) {
+	o.record("OnRollback", err, flags)
+}
+
+func (o *testObserver) OnRetry(err error, flags map[string]string) {
+	o.record("OnRetry", err, flags)
+}
+
+func (o *testObserver) OnSlowQuery(duration time.Duration, flags map[string]string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.events = append(o.events, observerEvent{
+		method:   "OnSlowQuery",
+		duration: duration,
+		flags:    flags,
+	})
+}
+
+func (o *testObserver) record(method string, err error, flags map[string]string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.events = append(o.events, observerEvent{
+		method: method,
+		err:    err,
+		flags:  flags,
+	})
+}
+
+func (o *testObserver) Events(method string) []observerEvent {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	events := []observerEvent{}
+	for _, event := range o.events {
+		if event.method == method {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
 // ----------------------------------------------------------------------------
 // Loader
 // ----------------------------------------------------------------------------
@@ -3434,9 +3685,17 @@ func dropTables(ctx context.Context, db *makroud.Client) {
 		-- Zootopia schema
 		--
 
+		DROP TABLE IF EXISTS ztp_fine CASCADE;
+		DROP TABLE IF EXISTS ztp_ticket CASCADE;
+		DROP TABLE IF EXISTS ztp_account CASCADE;
+		DROP TABLE IF EXISTS ztp_bunny CASCADE;
+		DROP TABLE IF EXISTS ztp_burrow CASCADE;
+		DROP TABLE IF EXISTS ztp_informant CASCADE;
+		DROP TABLE IF EXISTS ztp_kiosk CASCADE;
 		DROP TABLE IF EXISTS ztp_human CASCADE;
 		DROP TABLE IF EXISTS ztp_package CASCADE;
 		DROP TABLE IF EXISTS ztp_bag CASCADE;
+		DROP TABLE IF EXISTS ztp_roost CASCADE;
 		DROP TABLE IF EXISTS ztp_owl CASCADE;
 		DROP TABLE IF EXISTS ztp_cat CASCADE;
 		DROP TABLE IF EXISTS ztp_meow CASCADE;
@@ -3487,6 +3746,12 @@ func createTables(ctx context.Context, db *makroud.Client) {
 			favorite_food     VARCHAR(255) NOT NULL,
 			group_id          INTEGER REFERENCES ztp_group(id)
 		);
+		CREATE TABLE ztp_roost (
+			id                SERIAL PRIMARY KEY NOT NULL,
+			name              VARCHAR(255) NOT NULL,
+			group_id          INTEGER REFERENCES ztp_group(id),
+			deleted_at        TIMESTAMP WITH TIME ZONE
+		);
 		CREATE TABLE ztp_bag (
 			id                SERIAL PRIMARY KEY NOT NULL,
 			color             VARCHAR(255) NOT NULL,
@@ -3515,6 +3780,47 @@ func createTables(ctx context.Context, db *makroud.Client) {
 			updated_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
 			deleted_at        TIMESTAMP WITH TIME ZONE
 		);
+		CREATE TABLE ztp_kiosk (
+			id                SERIAL PRIMARY KEY NOT NULL,
+			name              VARCHAR(255) NOT NULL,
+			billing_street    VARCHAR(255) NOT NULL,
+			billing_city      VARCHAR(255) NOT NULL,
+			shipping_street   VARCHAR(255) NOT NULL,
+			shipping_city     VARCHAR(255) NOT NULL,
+			hours             JSONB,
+			manager           JSON,
+			tags              TEXT[],
+			sizes             SMALLINT[],
+			created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			updated_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			deleted_at        TIMESTAMP WITH TIME ZONE
+		);
+		CREATE TABLE ztp_burrow (
+			id                UUID PRIMARY KEY NOT NULL,
+			name              VARCHAR(255) NOT NULL
+		);
+		CREATE TABLE ztp_bunny (
+			id                SERIAL PRIMARY KEY NOT NULL,
+			name              VARCHAR(255) NOT NULL,
+			burrow_id         UUID NOT NULL REFERENCES ztp_burrow(id)
+		);
+		CREATE TABLE ztp_account (
+			id                SERIAL PRIMARY KEY NOT NULL,
+			name              VARCHAR(255) NOT NULL
+		);
+		CREATE TABLE ztp_ticket (
+			id                VARCHAR(32) PRIMARY KEY NOT NULL,
+			violation         VARCHAR(255) NOT NULL
+		);
+		CREATE TABLE ztp_fine (
+			id                BIGINT PRIMARY KEY NOT NULL,
+			amount            BIGINT NOT NULL
+		);
+		CREATE TABLE ztp_informant (
+			id                SERIAL PRIMARY KEY NOT NULL,
+			name              VARCHAR(255) NOT NULL,
+			alias             VARCHAR(255)
+		);
 		CREATE TABLE ztp_package (
 			id                VARCHAR(32) PRIMARY KEY NOT NULL DEFAULT md5(random()::text),
 			status            VARCHAR(255) NOT NULL,
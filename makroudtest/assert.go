@@ -0,0 +1,140 @@
+package makroudtest
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// TestingT is the subset of testing.TB used by assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertQuery asserts that a query matching given pattern, which is a regular expression, has been executed.
+func (d *Driver) AssertQuery(t TestingT, pattern string) bool {
+	t.Helper()
+
+	matcher := regexp.MustCompile(pattern)
+	for _, call := range d.Calls() {
+		if matcher.MatchString(call.Query) {
+			return true
+		}
+	}
+
+	t.Errorf("makroudtest: expected a query matching %q, got:\n%s", pattern, d.debugCalls())
+	return false
+}
+
+// AssertQueryArgs asserts that a query matching given pattern, which is a regular expression, has been executed
+// with given args.
+// Arguments are compared using their driver value: for example, an int is equal to an int64.
+func (d *Driver) AssertQueryArgs(t TestingT, pattern string, args ...interface{}) bool {
+	t.Helper()
+
+	matcher := regexp.MustCompile(pattern)
+	for _, call := range d.Calls() {
+		if matcher.MatchString(call.Query) && isArgsEqual(args, call.Args) {
+			return true
+		}
+	}
+
+	t.Errorf("makroudtest: expected a query matching %q with args %v, got:\n%s", pattern, args, d.debugCalls())
+	return false
+}
+
+// AssertNoQuery asserts that no query matching given pattern, which is a regular expression, has been executed.
+func (d *Driver) AssertNoQuery(t TestingT, pattern string) bool {
+	t.Helper()
+
+	matcher := regexp.MustCompile(pattern)
+	for _, call := range d.Calls() {
+		if matcher.MatchString(call.Query) {
+			t.Errorf("makroudtest: unexpected query matching %q: %s", pattern, call.Query)
+			return false
+		}
+	}
+
+	return true
+}
+
+// AssertTransactions asserts the number of transactions started, committed and rolled back.
+func (d *Driver) AssertTransactions(t TestingT, begins int, commits int, rollbacks int) bool {
+	t.Helper()
+
+	d.recorder.mutex.Lock()
+	defer d.recorder.mutex.Unlock()
+
+	if d.recorder.begins != begins || d.recorder.commits != commits || d.recorder.rollbacks != rollbacks {
+		t.Errorf("makroudtest: expected %d begins, %d commits and %d rollbacks, got %d, %d and %d",
+			begins, commits, rollbacks, d.recorder.begins, d.recorder.commits, d.recorder.rollbacks)
+		return false
+	}
+
+	return true
+}
+
+// AssertScriptsConsumed asserts that every scripted result has been returned.
+func (d *Driver) AssertScriptsConsumed(t TestingT) bool {
+	t.Helper()
+
+	d.recorder.mutex.Lock()
+	defer d.recorder.mutex.Unlock()
+
+	if len(d.recorder.scripts) > 0 {
+		patterns := make([]string, len(d.recorder.scripts))
+		for i := range d.recorder.scripts {
+			patterns[i] = d.recorder.scripts[i].pattern.String()
+		}
+		t.Errorf("makroudtest: expected every scripted result to be returned, remaining: %q", patterns)
+		return false
+	}
+
+	return true
+}
+
+// debugCalls returns a human readable version of executed queries.
+func (d *Driver) debugCalls() string {
+	calls := d.Calls()
+	if len(calls) == 0 {
+		return "  (no query)"
+	}
+
+	buffer := &bytes.Buffer{}
+	for i := range calls {
+		if i != 0 {
+			buffer.WriteString("\n")
+		}
+		buffer.WriteString("  ")
+		buffer.WriteString(calls[i].Query)
+		if len(calls[i].Args) > 0 {
+			fmt.Fprintf(buffer, " %v", calls[i].Args)
+		}
+	}
+	return buffer.String()
+}
+
+// isArgsEqual returns if given args are equal, using their driver value.
+func isArgsEqual(expected []interface{}, actual []interface{}) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(toDriverValue(expected[i]), toDriverValue(actual[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// toDriverValue converts given value to its driver value, if possible.
+func toDriverValue(value interface{}) interface{} {
+	converted, err := driver.DefaultParameterConverter.ConvertValue(value)
+	if err != nil {
+		return value
+	}
+	return converted
+}
@@ -0,0 +1,342 @@
+// Package makroudtest provides a makroud.Driver which records executed queries and returns scripted results,
+// so code using makroud can be unit tested without a database.
+package makroudtest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/ulule/makroud"
+)
+
+// Call is a query, or a statement, executed on a Driver.
+type Call struct {
+	// Query is the parameterised query.
+	Query string
+	// Args are the query arguments, as given to the driver.
+	Args []interface{}
+	// Transaction defines if the query was executed inside a transaction.
+	Transaction bool
+}
+
+// Driver is a makroud.Driver which records executed queries and returns scripted results, without a database.
+//
+// Queries executed by a transaction created with Begin are recorded by the Driver which created it.
+type Driver struct {
+	*makroud.Client
+	recorder *recorder
+}
+
+// New returns a new Driver instance, configured with given options.
+// A node given with makroud.WithNode is ignored.
+func New(options ...makroud.Option) (*Driver, error) {
+	opts := makroud.NewClientOptions()
+	for _, option := range options {
+		err := option(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recorder := &recorder{}
+
+	node := makroud.NewNode(sql.OpenDB(recorder))
+	node.EnableSavepoint(opts.SavepointEnabled)
+	opts.Node = node
+
+	client, err := makroud.NewWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Driver{
+		Client:   client,
+		recorder: recorder,
+	}, nil
+}
+
+// Return scripts the rows returned by the next query matching given pattern, which is a regular expression.
+// For a statement without rows, such as a DELETE, the number of rows defines the number of affected rows.
+// Scripts are consumed in order: if no script matches a query, it returns no rows.
+func (d *Driver) Return(pattern string, rows *Rows) {
+	if rows == nil {
+		rows = NewRows()
+	}
+	d.recorder.script(pattern, rows, nil)
+}
+
+// Fail scripts the error returned by the next query matching given pattern, which is a regular expression.
+func (d *Driver) Fail(pattern string, err error) {
+	if err == nil {
+		panic("makroudtest: an error is required")
+	}
+	d.recorder.script(pattern, nil, err)
+}
+
+// Calls returns the queries executed on this driver, in order.
+func (d *Driver) Calls() []Call {
+	d.recorder.mutex.Lock()
+	defer d.recorder.mutex.Unlock()
+
+	calls := make([]Call, len(d.recorder.calls))
+	copy(calls, d.recorder.calls)
+	return calls
+}
+
+// Begins returns the number of transactions started on this driver.
+func (d *Driver) Begins() int {
+	d.recorder.mutex.Lock()
+	defer d.recorder.mutex.Unlock()
+	return d.recorder.begins
+}
+
+// Commits returns the number of transactions committed on this driver.
+func (d *Driver) Commits() int {
+	d.recorder.mutex.Lock()
+	defer d.recorder.mutex.Unlock()
+	return d.recorder.commits
+}
+
+// Rollbacks returns the number of transactions rolled back on this driver.
+func (d *Driver) Rollbacks() int {
+	d.recorder.mutex.Lock()
+	defer d.recorder.mutex.Unlock()
+	return d.recorder.rollbacks
+}
+
+// Reset clears the recorded queries, the pending scripts and the transactions bookkeeping.
+func (d *Driver) Reset() {
+	d.recorder.mutex.Lock()
+	defer d.recorder.mutex.Unlock()
+
+	d.recorder.calls = nil
+	d.recorder.scripts = nil
+	d.recorder.begins = 0
+	d.recorder.commits = 0
+	d.recorder.rollbacks = 0
+}
+
+// ----------------------------------------------------------------------------
+// Recorder
+// ----------------------------------------------------------------------------
+
+// script is a result returned by the next query matching its pattern.
+type script struct {
+	pattern *regexp.Regexp
+	rows    *Rows
+	err     error
+}
+
+// recorder is a driver.Connector which records every query executed on its connections.
+type recorder struct {
+	mutex     sync.Mutex
+	calls     []Call
+	scripts   []script
+	begins    int
+	commits   int
+	rollbacks int
+}
+
+// Connect implements driver.Connector interface.
+func (r *recorder) Connect(ctx context.Context) (driver.Conn, error) {
+	return &conn{recorder: r}, nil
+}
+
+// Driver implements driver.Connector interface.
+func (r *recorder) Driver() driver.Driver {
+	return connector{recorder: r}
+}
+
+func (r *recorder) script(pattern string, rows *Rows, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.scripts = append(r.scripts, script{
+		pattern: regexp.MustCompile(pattern),
+		rows:    rows,
+		err:     err,
+	})
+}
+
+// record records given query and returns the first script matching it, if any.
+func (r *recorder) record(query string, args []driver.NamedValue, tx bool) (*Rows, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	call := Call{
+		Query:       query,
+		Args:        make([]interface{}, len(args)),
+		Transaction: tx,
+	}
+	for i := range args {
+		call.Args[i] = args[i].Value
+	}
+
+	r.calls = append(r.calls, call)
+
+	for i := range r.scripts {
+		if r.scripts[i].pattern.MatchString(query) {
+			script := r.scripts[i]
+			r.scripts = append(r.scripts[:i], r.scripts[i+1:]...)
+			return script.rows, script.err
+		}
+	}
+
+	return NewRows(), nil
+}
+
+// connector is a driver.Driver which opens connections on a recorder.
+type connector struct {
+	recorder *recorder
+}
+
+// Open implements driver.Driver interface.
+func (c connector) Open(name string) (driver.Conn, error) {
+	return &conn{recorder: c.recorder}, nil
+}
+
+// conn is a driver.Conn of a recorder.
+type conn struct {
+	recorder *recorder
+	tx       bool
+}
+
+// Prepare implements driver.Conn interface.
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+// Close implements driver.Conn interface.
+func (c *conn) Close() error {
+	return nil
+}
+
+// Begin implements driver.Conn interface.
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx implements driver.ConnBeginTx interface.
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.tx {
+		return nil, errors.New("makroudtest: a transaction is already started")
+	}
+
+	c.recorder.mutex.Lock()
+	defer c.recorder.mutex.Unlock()
+
+	c.tx = true
+	c.recorder.begins++
+
+	return &tx{conn: c}, nil
+}
+
+// Ping implements driver.Pinger interface.
+func (c *conn) Ping(ctx context.Context) error {
+	return nil
+}
+
+// CheckNamedValue implements driver.NamedValueChecker interface: arguments are recorded as given.
+func (c *conn) CheckNamedValue(value *driver.NamedValue) error {
+	return nil
+}
+
+// ExecContext implements driver.ExecerContext interface.
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	rows, err := c.recorder.record(query, args, c.tx)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(len(rows.values)), nil
+}
+
+// QueryContext implements driver.QueryerContext interface.
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.recorder.record(query, args, c.tx)
+	if err != nil {
+		return nil, err
+	}
+	return rows.iterator()
+}
+
+// tx is a driver.Tx of a recorder.
+type tx struct {
+	conn *conn
+}
+
+// Commit implements driver.Tx interface.
+func (t *tx) Commit() error {
+	t.conn.recorder.mutex.Lock()
+	defer t.conn.recorder.mutex.Unlock()
+
+	t.conn.tx = false
+	t.conn.recorder.commits++
+	return nil
+}
+
+// Rollback implements driver.Tx interface.
+func (t *tx) Rollback() error {
+	t.conn.recorder.mutex.Lock()
+	defer t.conn.recorder.mutex.Unlock()
+
+	t.conn.tx = false
+	t.conn.recorder.rollbacks++
+	return nil
+}
+
+// stmt is a driver.Stmt of a recorder.
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+// Close implements driver.Stmt interface.
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput implements driver.Stmt interface.
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+// Exec implements driver.Stmt interface.
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), toNamedValues(args))
+}
+
+// Query implements driver.Stmt interface.
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), toNamedValues(args))
+}
+
+// ExecContext implements driver.StmtExecContext interface.
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+// QueryContext implements driver.StmtQueryContext interface.
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+// CheckNamedValue implements driver.NamedValueChecker interface: arguments are recorded as given.
+func (s *stmt) CheckNamedValue(value *driver.NamedValue) error {
+	return nil
+}
+
+func toNamedValues(args []driver.Value) []driver.NamedValue {
+	values := make([]driver.NamedValue, len(args))
+	for i := range args {
+		values[i] = driver.NamedValue{
+			Ordinal: i + 1,
+			Value:   args[i],
+		}
+	}
+	return values
+}
@@ -0,0 +1,214 @@
+package makroudtest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/ulule/loukoum/v3"
+
+	"github.com/ulule/makroud"
+	"github.com/ulule/makroud/makroudtest"
+)
+
+type Burrow struct {
+	// Columns
+	ID        int64     `makroud:"column:id,pk"`
+	Name      string    `makroud:"column:name"`
+	CreatedAt time.Time `makroud:"column:created_at,default"`
+	// Relationships
+	Rabbits []Rabbit
+}
+
+func (Burrow) TableName() string {
+	return "mkt_burrow"
+}
+
+type Rabbit struct {
+	// Columns
+	ID       int64  `makroud:"column:id,pk"`
+	Name     string `makroud:"column:name"`
+	BurrowID int64  `makroud:"column:burrow_id,fk:mkt_burrow"`
+}
+
+func (Rabbit) TableName() string {
+	return "mkt_rabbit"
+}
+
+type testingT struct {
+	errors []string
+}
+
+func (t *testingT) Helper() {}
+
+func (t *testingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestDriver_Save(t *testing.T) {
+	ctx := context.Background()
+	is := require.New(t)
+
+	driver, err := makroudtest.New(makroud.Cache(true))
+	is.NoError(err)
+
+	created := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	driver.Return(`^INSERT INTO mkt_burrow`, makroudtest.NewRows("id", "created_at").AddRow(42, created))
+
+	burrow := &Burrow{Name: "Warren"}
+	err = makroud.Save(ctx, driver, burrow)
+	is.NoError(err)
+	is.Equal(int64(42), burrow.ID)
+	is.Equal(created, burrow.CreatedAt)
+
+	burrow.Name = "Watership Down"
+	err = makroud.Save(ctx, driver, burrow)
+	is.NoError(err)
+
+	is.True(driver.AssertQuery(t, `^INSERT INTO mkt_burrow \(name\) VALUES \(\$1\) RETURNING created_at, id$`))
+	is.True(driver.AssertQueryArgs(t, `^INSERT INTO mkt_burrow`, "Warren"))
+	is.True(driver.AssertQueryArgs(t, `^UPDATE mkt_burrow`, created, "Watership Down", 42))
+	is.True(driver.AssertNoQuery(t, `^DELETE`))
+	is.True(driver.AssertScriptsConsumed(t))
+
+	calls := driver.Calls()
+	is.Len(calls, 2)
+	is.False(calls[0].Transaction)
+
+	driver.Fail(`^DELETE FROM mkt_burrow`, errors.New("connection reset"))
+	err = makroud.Delete(ctx, driver, burrow)
+	is.Error(err)
+	is.Contains(err.Error(), "connection reset")
+}
+
+func TestDriver_Select(t *testing.T) {
+	ctx := context.Background()
+	is := require.New(t)
+
+	driver, err := makroudtest.New(makroud.Cache(true))
+	is.NoError(err)
+
+	driver.Return(`FROM mkt_burrow`, makroudtest.NewRows("id", "name").
+		AddRow(1, "Warren").
+		AddRow(2, "Efrafa"))
+
+	burrows := []Burrow{}
+	err = makroud.Select(ctx, driver, &burrows, loukoum.Order("id"))
+	is.NoError(err)
+	is.Len(burrows, 2)
+	is.Equal("Efrafa", burrows[1].Name)
+	is.True(driver.AssertQuery(t, `^SELECT .* FROM mkt_burrow ORDER BY id ASC$`))
+
+	burrow := &Burrow{}
+	err = makroud.Select(ctx, driver, burrow, loukoum.Condition("id").Equal(3))
+	is.Error(err)
+	is.True(makroud.IsErrNoRows(err))
+	is.True(driver.AssertQueryArgs(t, `FROM mkt_burrow WHERE`, int64(3)))
+}
+
+func TestDriver_Preload(t *testing.T) {
+	ctx := context.Background()
+	is := require.New(t)
+
+	driver, err := makroudtest.New(makroud.Cache(true))
+	is.NoError(err)
+
+	driver.Return(`FROM mkt_burrow`, makroudtest.NewRows("id", "name").
+		AddRow(1, "Warren").
+		AddRow(2, "Efrafa").
+		AddRow(3, "Nuthanger"))
+	driver.Return(`FROM mkt_rabbit`, makroudtest.NewRows("id", "name", "burrow_id").
+		AddRow(10, "Hazel", 1).
+		AddRow(11, "Fiver", 1).
+		AddRow(12, "Woundwort", 2))
+
+	burrows := []Burrow{}
+	err = makroud.Select(ctx, driver, &burrows, loukoum.Order("id"))
+	is.NoError(err)
+	is.Len(burrows, 3)
+
+	err = makroud.Preload(ctx, driver, &burrows, makroud.WithPreloadField("Rabbits"))
+	is.NoError(err)
+	is.Len(burrows[0].Rabbits, 2)
+	is.Equal("Hazel", burrows[0].Rabbits[0].Name)
+	is.Equal("Fiver", burrows[0].Rabbits[1].Name)
+	is.Equal(int64(1), burrows[0].Rabbits[1].BurrowID)
+	is.Len(burrows[1].Rabbits, 1)
+	is.Equal("Woundwort", burrows[1].Rabbits[0].Name)
+	is.Empty(burrows[2].Rabbits)
+
+	is.True(driver.AssertQuery(t, `^SELECT .* FROM mkt_rabbit WHERE \(mkt_rabbit.burrow_id IN \(\$1, \$2, \$3\)\)$`))
+	is.True(driver.AssertScriptsConsumed(t))
+
+	// Foreign keys are collected without a defined order.
+	calls := driver.Calls()
+	is.Len(calls, 2)
+	is.Contains(calls[1].Query, "FROM mkt_rabbit")
+	is.ElementsMatch([]interface{}{int64(1), int64(2), int64(3)}, calls[1].Args)
+}
+
+func TestDriver_Transaction(t *testing.T) {
+	ctx := context.Background()
+	is := require.New(t)
+
+	driver, err := makroudtest.New()
+	is.NoError(err)
+
+	driver.Return(`^INSERT INTO mkt_rabbit`, makroudtest.NewRows("id").AddRow(1))
+
+	err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+		return makroud.Save(ctx, tx, &Rabbit{Name: "Bigwig", BurrowID: 1})
+	})
+	is.NoError(err)
+	is.True(driver.AssertTransactions(t, 1, 1, 0))
+
+	calls := driver.Calls()
+	is.Len(calls, 1)
+	is.True(calls[0].Transaction)
+
+	err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+		return makroud.Save(ctx, tx, &Rabbit{Name: "Blackberry", BurrowID: 1})
+	})
+	is.Error(err)
+	is.True(driver.AssertTransactions(t, 2, 1, 1))
+
+	driver.Reset()
+	is.Empty(driver.Calls())
+	is.Equal(0, driver.Begins())
+	is.Equal(0, driver.Commits())
+	is.Equal(0, driver.Rollbacks())
+}
+
+func TestDriver_Assertions(t *testing.T) {
+	ctx := context.Background()
+	is := require.New(t)
+
+	driver, err := makroudtest.New()
+	is.NoError(err)
+
+	mock := &testingT{}
+	is.False(driver.AssertQuery(mock, `^SELECT`))
+	is.Len(mock.errors, 1)
+	is.Contains(mock.errors[0], "(no query)")
+
+	err = driver.Exec(ctx, "DELETE FROM mkt_rabbit WHERE id = $1", 4)
+	is.NoError(err)
+
+	mock = &testingT{}
+	is.True(driver.AssertQueryArgs(mock, `^DELETE FROM mkt_rabbit`, int64(4)))
+	is.False(driver.AssertQueryArgs(mock, `^DELETE FROM mkt_rabbit`, 5))
+	is.False(driver.AssertNoQuery(mock, `^DELETE`))
+	is.False(driver.AssertTransactions(mock, 1, 1, 0))
+	is.Len(mock.errors, 3)
+	is.Contains(mock.errors[0], "DELETE FROM mkt_rabbit WHERE id = $1 [4]")
+
+	driver.Return(`^UPDATE`, nil)
+
+	mock = &testingT{}
+	is.False(driver.AssertScriptsConsumed(mock))
+	is.Len(mock.errors, 1)
+	is.Contains(mock.errors[0], "^UPDATE")
+}
@@ -0,0 +1,80 @@
+//go:build go1.14
+// +build go1.14
+
+package makroudtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ulule/makroud"
+)
+
+// Harness opens a transaction per test on a shared Client, which is rolled back once the test is done: tables
+// don't have to be truncated between tests.
+//
+// Savepoints are always enabled, so a nested Transaction executed with the Driver of a test is a savepoint of
+// the test transaction. Since every transaction uses its own connection, parallel tests are isolated
+// from each other.
+type Harness struct {
+	client *makroud.Client
+}
+
+// NewHarness returns a new Harness instance, using a Client configured with given options.
+func NewHarness(options ...makroud.Option) (*Harness, error) {
+	opts := makroud.NewClientOptions()
+	for _, option := range options {
+		err := option(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts.SavepointEnabled = true
+	if opts.Node != nil {
+		opts.Node.EnableSavepoint(true)
+	}
+
+	client, err := makroud.NewWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Harness{
+		client: client,
+	}, nil
+}
+
+// Client returns the shared Client, for example to create tables before the tests.
+// Queries executed with this Client are not rolled back.
+func (harness *Harness) Client() *makroud.Client {
+	return harness.client
+}
+
+// Begin starts a transaction for given test, which is rolled back once the test and its subtests are done.
+// The returned Driver must not be committed or rolled back by the test. Since it's never committed,
+// callbacks registered with makroud.OnCommit are never executed.
+//
+// Begin relies on testing.TB.Cleanup, which requires Go 1.14.
+func (harness *Harness) Begin(t testing.TB) makroud.Driver {
+	t.Helper()
+
+	driver, err := harness.client.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("makroudtest: cannot start test transaction: %+v", err)
+	}
+
+	t.Cleanup(func() {
+		err := driver.Rollback()
+		if err != nil {
+			t.Errorf("makroudtest: cannot rollback test transaction: %+v", err)
+		}
+	})
+
+	return driver
+}
+
+// Close closes the shared Client.
+func (harness *Harness) Close() error {
+	return harness.client.Close()
+}
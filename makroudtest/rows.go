@@ -0,0 +1,81 @@
+package makroudtest
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Rows is a scripted result, defined by its column names and its values.
+type Rows struct {
+	columns []string
+	values  [][]interface{}
+}
+
+// NewRows returns a new Rows instance with given column names.
+func NewRows(columns ...string) *Rows {
+	return &Rows{
+		columns: columns,
+		values:  [][]interface{}{},
+	}
+}
+
+// AddRow appends a row with given values, which are given in the same order as the column names.
+// A value can be any type accepted as a query argument, such as an int, a time.Time or a driver.Valuer.
+func (rows *Rows) AddRow(values ...interface{}) *Rows {
+	if len(values) != len(rows.columns) {
+		panic(fmt.Sprintf("makroudtest: expected %d values, got %d", len(rows.columns), len(values)))
+	}
+	rows.values = append(rows.values, values)
+	return rows
+}
+
+// iterator returns a driver.Rows of these values.
+func (rows *Rows) iterator() (driver.Rows, error) {
+	iterator := &rowsIterator{
+		columns: rows.columns,
+		values:  make([][]driver.Value, len(rows.values)),
+	}
+
+	for i := range rows.values {
+		iterator.values[i] = make([]driver.Value, len(rows.values[i]))
+		for j := range rows.values[i] {
+			value, err := driver.DefaultParameterConverter.ConvertValue(rows.values[i][j])
+			if err != nil {
+				return nil, errors.Wrapf(err, "makroudtest: cannot use value of column %s", rows.columns[j])
+			}
+			iterator.values[i][j] = value
+		}
+	}
+
+	return iterator, nil
+}
+
+// rowsIterator is a driver.Rows of scripted values.
+type rowsIterator struct {
+	columns []string
+	values  [][]driver.Value
+	index   int
+}
+
+// Columns implements driver.Rows interface.
+func (iterator *rowsIterator) Columns() []string {
+	return iterator.columns
+}
+
+// Close implements driver.Rows interface.
+func (iterator *rowsIterator) Close() error {
+	return nil
+}
+
+// Next implements driver.Rows interface.
+func (iterator *rowsIterator) Next(dest []driver.Value) error {
+	if iterator.index >= len(iterator.values) {
+		return io.EOF
+	}
+	copy(dest, iterator.values[iterator.index])
+	iterator.index++
+	return nil
+}
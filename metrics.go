@@ -0,0 +1,153 @@
+package makroud
+
+import (
+	"context"
+	"time"
+
+	"github.com/ulule/makroud/hooks"
+)
+
+// List of metrics given to a MetricsRegistry.
+const (
+	// MetricOperationDuration is an histogram of queries duration, in seconds, by operation.
+	MetricOperationDuration = "makroud_operation_duration_seconds"
+	// MetricErrors is a counter of queries errors, by operation and SQLSTATE class.
+	MetricErrors = "makroud_errors_total"
+	// MetricTransactions is a counter of transactions, by type (transaction, savepoint or nested) and
+	// outcome (commit, rollback or commit_error).
+	MetricTransactions = "makroud_transactions_total"
+	// MetricPoolMaxOpenConnections is a gauge of the maximum number of open connections.
+	MetricPoolMaxOpenConnections = "makroud_pool_max_open_connections"
+	// MetricPoolOpenConnections is a gauge of the number of open connections.
+	MetricPoolOpenConnections = "makroud_pool_open_connections"
+	// MetricPoolInUseConnections is a gauge of the number of connections currently in use.
+	MetricPoolInUseConnections = "makroud_pool_in_use_connections"
+	// MetricPoolIdleConnections is a gauge of the number of idle connections.
+	MetricPoolIdleConnections = "makroud_pool_idle_connections"
+	// MetricPoolWaitCount is a gauge of the total number of connections waited for.
+	MetricPoolWaitCount = "makroud_pool_wait_count"
+	// MetricPoolWaitDuration is a gauge of the total time blocked waiting for a new connection, in seconds.
+	MetricPoolWaitDuration = "makroud_pool_wait_duration_seconds"
+)
+
+// MetricsRegistry is a collector that gathers metrics, it's designed to be easily adapted to Prometheus.
+type MetricsRegistry interface {
+	// IncCounter increments the counter with given name and labels.
+	IncCounter(name string, labels map[string]string)
+	// ObserveHistogram adds an observation to the histogram with given name and labels.
+	ObserveHistogram(name string, labels map[string]string, value float64)
+	// SetGauge defines the value of the gauge with given name and labels.
+	SetGauge(name string, labels map[string]string, value float64)
+}
+
+// CollectorRegistry is an optional MetricsRegistry which executes collectors every time its metrics are gathered,
+// such as a Prometheus registry: connections pool statistics are then sampled on collection.
+type CollectorRegistry interface {
+	MetricsRegistry
+	// RegisterCollector registers a function executed every time metrics are gathered.
+	RegisterCollector(collector func())
+}
+
+// ObservePoolMetrics reports the connections pool statistics of given driver to its metrics registry.
+// With a registry which isn't a CollectorRegistry, it could be executed periodically to sample these statistics.
+func ObservePoolMetrics(driver Driver) {
+	client := getClient(driver)
+	if client == nil || client.metrics() == nil {
+		return
+	}
+
+	observePoolMetrics(client)
+}
+
+// getMetricsRegistry returns the metrics registry of given driver, if any.
+func getMetricsRegistry(driver Driver) (MetricsRegistry, bool) {
+	client := getClient(driver)
+	if client == nil || client.metrics() == nil {
+		return nil, false
+	}
+	return client.metrics(), true
+}
+
+// observeQueryMetrics reports the duration and the error, if any, of a query to the driver metrics registry.
+func observeQueryMetrics(ctx context.Context, driver Driver, duration time.Duration, err error) {
+	registry, ok := getMetricsRegistry(driver)
+	if !ok {
+		return
+	}
+
+	operation, ok := hooks.GetOperation(ctx)
+	if !ok {
+		operation = "Exec"
+	}
+
+	registry.ObserveHistogram(MetricOperationDuration, map[string]string{
+		"operation": operation,
+	}, duration.Seconds())
+
+	if err != nil && !IsErrNoRows(err) {
+		class := "other"
+		thr, ok := getPostgresError(err)
+		if ok {
+			class = string(thr.Code.Class())
+		}
+
+		registry.IncCounter(MetricErrors, map[string]string{
+			"operation": operation,
+			"class":     class,
+		})
+	}
+}
+
+// observeTransactionMetrics reports the outcome of given transaction to its metrics registry.
+func observeTransactionMetrics(tx Driver, outcome string) {
+	registry, ok := getMetricsRegistry(tx)
+	if !ok {
+		return
+	}
+
+	registry.IncCounter(MetricTransactions, map[string]string{
+		"type":    getTransactionType(getClient(tx).node),
+		"outcome": outcome,
+	})
+}
+
+// getTransactionType returns the type of transaction of given node: an outermost "transaction", a nested one
+// using a "savepoint", or a "nested" one reusing its parent transaction, if savepoints are disabled.
+func getTransactionType(connection Node) string {
+	tx, ok := connection.(*node)
+	switch {
+	case !ok || !tx.nested:
+		return "transaction"
+	case tx.savePointID != "":
+		return "savepoint"
+	default:
+		return "nested"
+	}
+}
+
+// registerPoolMetrics registers the connections pool statistics of given client on its metrics registry,
+// if it's a CollectorRegistry.
+func registerPoolMetrics(client *Client) {
+	registry, ok := client.metrics().(CollectorRegistry)
+	if !ok {
+		return
+	}
+
+	registry.RegisterCollector(func() {
+		observePoolMetrics(client)
+	})
+}
+
+// observePoolMetrics reports the connections pool statistics of given client to its metrics registry.
+func observePoolMetrics(client *Client) {
+	stats := client.Stats()
+	registry := client.metrics()
+	labels := map[string]string{}
+
+	registry.SetGauge(MetricPoolMaxOpenConnections, labels, float64(stats.MaxOpenConnections))
+	registry.SetGauge(MetricPoolOpenConnections, labels, float64(stats.OpenConnections))
+	registry.SetGauge(MetricPoolInUseConnections, labels, float64(stats.InUse))
+	registry.SetGauge(MetricPoolIdleConnections, labels, float64(stats.Idle))
+	registry.SetGauge(MetricPoolWaitCount, labels, float64(stats.WaitCount))
+	registry.SetGauge(MetricPoolWaitDuration, labels, stats.WaitDuration.Seconds())
+}
@@ -0,0 +1,169 @@
+package makroud_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulule/loukoum/v3"
+
+	"github.com/ulule/makroud"
+	"github.com/ulule/makroud/makroudtest"
+)
+
+type registry struct {
+	mutex      sync.Mutex
+	counters   map[string]int
+	histograms map[string]int
+	gauges     map[string]float64
+	collectors []func()
+}
+
+func newRegistry() *registry {
+	return &registry{
+		counters:   map[string]int{},
+		histograms: map[string]int{},
+		gauges:     map[string]float64{},
+	}
+}
+
+func metricKey(name string, labels map[string]string) string {
+	keys := []string{}
+	for key, value := range labels {
+		keys = append(keys, fmt.Sprint(key, "=", value))
+	}
+	sort.Strings(keys)
+	return fmt.Sprint(name, "{", strings.Join(keys, ","), "}")
+}
+
+func (r *registry) IncCounter(name string, labels map[string]string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.counters[metricKey(name, labels)]++
+}
+
+func (r *registry) ObserveHistogram(name string, labels map[string]string, value float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.histograms[metricKey(name, labels)]++
+}
+
+func (r *registry) SetGauge(name string, labels map[string]string, value float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.gauges[metricKey(name, labels)] = value
+}
+
+func (r *registry) RegisterCollector(collector func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.collectors = append(r.collectors, collector)
+}
+
+func (r *registry) Collect() {
+	r.mutex.Lock()
+	collectors := append([]func(){}, r.collectors...)
+	r.mutex.Unlock()
+	for _, collector := range collectors {
+		collector()
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	registry := newRegistry()
+	Setup(t, makroud.WithMetrics(registry), makroud.EnableSavepoint())(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		cat := &Cat{Name: "Nala"}
+		err := makroud.Save(ctx, driver, cat)
+		is.NoError(err)
+
+		cats := []Cat{}
+		err = makroud.Select(ctx, driver, &cats)
+		is.NoError(err)
+
+		err = makroud.Preload(ctx, driver, &cats, makroud.WithPreloadField("Meows"))
+		is.NoError(err)
+
+		err = makroud.Delete(ctx, driver, cat)
+		is.NoError(err)
+
+		err = makroud.RawExec(ctx, driver, `SELECT * FROM ztp_unknown`)
+		is.Error(err)
+
+		err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+			_ = makroud.Transaction(ctx, tx, nil, func(tx makroud.Driver) error {
+				return makroud.ErrNoRows
+			})
+			return nil
+		})
+		is.NoError(err)
+
+		is.Equal(1, registry.histograms[`makroud_operation_duration_seconds{operation=Save}`])
+		is.Equal(1, registry.histograms[`makroud_operation_duration_seconds{operation=Select}`])
+		is.Equal(1, registry.histograms[`makroud_operation_duration_seconds{operation=Preload}`])
+		is.Equal(1, registry.histograms[`makroud_operation_duration_seconds{operation=Delete}`])
+		is.Equal(1, registry.histograms[`makroud_operation_duration_seconds{operation=Exec}`])
+		is.Equal(1, registry.counters[`makroud_errors_total{class=42,operation=Exec}`])
+		is.Equal(1, registry.counters[`makroud_transactions_total{outcome=commit,type=transaction}`])
+		is.Equal(1, registry.counters[`makroud_transactions_total{outcome=rollback,type=savepoint}`])
+		is.Empty(registry.gauges)
+
+		registry.Collect()
+		is.Equal(float64(5), registry.gauges[`makroud_pool_max_open_connections{}`])
+		is.Contains(registry.gauges, `makroud_pool_open_connections{}`)
+
+		query := loukoum.Select("id").From("ztp_cat").Where(loukoum.Condition("id").Equal(cat.ID))
+		err = makroud.Exec(ctx, driver, query, &cat.ID)
+		is.True(makroud.IsErrNoRows(err))
+		is.Len(registry.counters, 3)
+	})
+}
+
+func TestMetrics_Transactions(t *testing.T) {
+	ctx := context.Background()
+	is := require.New(t)
+
+	registry := newRegistry()
+	driver, err := makroudtest.New(makroud.WithMetrics(registry))
+	is.NoError(err)
+
+	err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+		return makroud.Transaction(ctx, tx, nil, func(tx makroud.Driver) error {
+			return makroud.ErrNoRows
+		})
+	})
+	is.Error(err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	err = makroud.Transaction(canceled, driver, nil, func(tx makroud.Driver) error {
+		cancel()
+		return nil
+	})
+	is.Error(err)
+
+	is.Equal(1, registry.counters[`makroud_transactions_total{outcome=rollback,type=nested}`])
+	is.Equal(1, registry.counters[`makroud_transactions_total{outcome=rollback,type=transaction}`])
+	is.Equal(1, registry.counters[`makroud_transactions_total{outcome=commit_error,type=transaction}`])
+	is.Len(registry.counters, 3)
+
+	registry = newRegistry()
+	driver, err = makroudtest.New(makroud.WithMetrics(registry), makroud.EnableSavepoint())
+	is.NoError(err)
+
+	err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+		return makroud.Transaction(ctx, tx, nil, func(tx makroud.Driver) error {
+			return nil
+		})
+	})
+	is.NoError(err)
+
+	is.Equal(1, registry.counters[`makroud_transactions_total{outcome=commit,type=savepoint}`])
+	is.Equal(1, registry.counters[`makroud_transactions_total{outcome=commit,type=transaction}`])
+	is.Len(registry.counters, 2)
+}
@@ -3,6 +3,7 @@ package makroud
 import (
 	"context"
 	"database/sql"
+	sqldriver "database/sql/driver"
 	"strings"
 	"time"
 
@@ -96,6 +97,13 @@ type Node interface {
 	DB() *sql.DB
 }
 
+// isReusedTransaction returns if given node is a nested transaction reusing its parent one, without savepoint:
+// its commit and its rollback are no-ops, so its queries are committed or rolled back with the outermost transaction.
+func isReusedTransaction(connection Node) bool {
+	nested, ok := connection.(*node)
+	return ok && nested.nested && nested.savePointID == ""
+}
+
 type node struct {
 	driver           string
 	db               *sql.DB
@@ -128,6 +136,26 @@ func Connect(driver string, dsn string) (Node, error) {
 	return node, nil
 }
 
+// ConnectWithConnector connects to a database using given connector and verifies connection with a ping.
+func ConnectWithConnector(driver string, connector sqldriver.Connector) (Node, error) {
+	db := sql.OpenDB(connector)
+
+	err := db.Ping()
+	if err != nil {
+		// the connection has been opened within this function, we must close it
+		// on error.
+		_ = db.Close()
+		return nil, err
+	}
+
+	node := &node{
+		driver: driver,
+		db:     db,
+	}
+
+	return node, nil
+}
+
 // NewNode returns a new Node.
 func NewNode(db *sql.DB) Node {
 	return &node{db: db}
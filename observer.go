@@ -1,5 +1,9 @@
 package makroud
 
+import (
+	"time"
+)
+
 // Observer is a collector that gathers various runtime error.
 type Observer interface {
 	// OnClose
@@ -7,3 +11,31 @@ type Observer interface {
 	// OnRollback
 	OnRollback(err error, flags map[string]string)
 }
+
+// HealthObserver is an optional Observer that is also notified when the health of a Selector connection changes.
+type HealthObserver interface {
+	Observer
+	// OnHealthCheck is called when a health check ejects a connection, with the ping error,
+	// or reinstates it, with a nil error.
+	// Its flags define the connection "alias" and the "action", either "eject" or "reinstate".
+	OnHealthCheck(err error, flags map[string]string)
+}
+
+// RetryObserver is an optional Observer that is also notified when TransactionWithRetry reruns a transaction.
+type RetryObserver interface {
+	Observer
+	// OnRetry is called before a transaction is rerun, with the serialization failure or deadlock of the
+	// previous attempt.
+	// Its flags define the "attempt" which failed and the SQLSTATE "code" of its error.
+	OnRetry(err error, flags map[string]string)
+}
+
+// SlowQueryObserver is an optional Observer that is also notified of queries slower than the driver threshold,
+// defined with SlowQueryThreshold.
+type SlowQueryObserver interface {
+	Observer
+	// OnSlowQuery is called once a query took longer than the threshold, with its duration, even if it has failed.
+	// Its flags define the "query", its "fingerprint", the "threshold" and, if the query has failed, its "error".
+	// With ExplainSlowQueries, the first report of a fingerprint also defines its JSON "plan".
+	OnSlowQuery(duration time.Duration, flags map[string]string)
+}
@@ -4,29 +4,42 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/ulule/makroud/hooks"
 )
 
 // ClientOptions configure a Client instance.
 type ClientOptions struct {
-	Port               int
-	Host               string
-	User               string
-	Password           string
-	Database           string
-	SSLMode            string
-	Timezone           string
-	MaxOpenConnections int
-	MaxIdleConnections int
-	WithCache          bool
-	SavepointEnabled   bool
-	ApplicationName    string
-	ConnectTimeout     int
-	Logger             Logger
-	Observer           Observer
-	Entropy            io.Reader
-	Node               Node
+	Port                 int
+	Host                 string
+	User                 string
+	Password             string
+	Database             string
+	SSLMode              string
+	Timezone             string
+	MaxOpenConnections   int
+	MaxIdleConnections   int
+	WithCache            bool
+	SavepointEnabled     bool
+	ApplicationName      string
+	ConnectTimeout       int
+	Logger               Logger
+	Observer             Observer
+	Entropy              io.Reader
+	Node                 Node
+	TenantColumn         string
+	RedactArgs           bool
+	SlowQueryThreshold   time.Duration
+	ExplainSlowQueries   bool
+	Tracer               hooks.Tracer
+	Metrics              MetricsRegistry
+	Codecs               map[string]Codec
+	PrimaryKeyGenerators map[string]PrimaryKeyGenerator
+	Clock                Clock
+	LenientScan          bool
 }
 
 func (e ClientOptions) String() string {
@@ -52,23 +65,33 @@ func (e ClientOptions) String() string {
 // NewClientOptions creates a new ClientOptions instance with default options.
 func NewClientOptions() *ClientOptions {
 	return &ClientOptions{
-		Host:               "localhost",
-		Port:               5432,
-		User:               "postgres",
-		Password:           "",
-		Database:           "postgres",
-		SSLMode:            "disable",
-		Timezone:           "UTC",
-		MaxOpenConnections: 5,
-		MaxIdleConnections: 2,
-		WithCache:          true,
-		SavepointEnabled:   false,
-		ApplicationName:    "Makroud",
-		ConnectTimeout:     10,
-		Logger:             nil,
-		Observer:           nil,
-		Entropy:            nil,
-		Node:               nil,
+		Host:                 "localhost",
+		Port:                 5432,
+		User:                 "postgres",
+		Password:             "",
+		Database:             "postgres",
+		SSLMode:              "disable",
+		Timezone:             "UTC",
+		MaxOpenConnections:   5,
+		MaxIdleConnections:   2,
+		WithCache:            true,
+		SavepointEnabled:     false,
+		ApplicationName:      "Makroud",
+		ConnectTimeout:       10,
+		Logger:               nil,
+		Observer:             nil,
+		Entropy:              nil,
+		Node:                 nil,
+		TenantColumn:         "",
+		RedactArgs:           false,
+		SlowQueryThreshold:   0,
+		ExplainSlowQueries:   false,
+		Tracer:               nil,
+		Metrics:              nil,
+		Codecs:               map[string]Codec{},
+		PrimaryKeyGenerators: map[string]PrimaryKeyGenerator{},
+		Clock:                nil,
+		LenientScan:          false,
 	}
 }
 
@@ -253,3 +276,125 @@ func ConnectTimeout(timeout int) Option {
 		return nil
 	}
 }
+
+// WithTenantColumn will configure the Client to isolate rows using given tenant column.
+// Every schema having this column will require a tenant in context, defined with WithTenant.
+func WithTenantColumn(column string) Option {
+	return func(options *ClientOptions) error {
+		if column == "" {
+			return errors.New("makroud: a tenant column is required")
+		}
+		options.TenantColumn = column
+		return nil
+	}
+}
+
+// RedactArgs will configure the Client to hide arguments values in events given to an EventLogger.
+func RedactArgs() Option {
+	return func(options *ClientOptions) error {
+		options.RedactArgs = true
+		return nil
+	}
+}
+
+// LenientScan will configure the Client to discard columns without destination in a model, instead of returning
+// an error. It can be overridden per query with WithLenientScan.
+func LenientScan() Option {
+	return func(options *ClientOptions) error {
+		options.LenientScan = true
+		return nil
+	}
+}
+
+// SlowQueryThreshold will configure the Client to report queries above given duration to its observer,
+// if it implements SlowQueryObserver.
+func SlowQueryThreshold(threshold time.Duration) Option {
+	return func(options *ClientOptions) error {
+		if threshold <= 0 {
+			return errors.New("makroud: slow query threshold must be greater than zero")
+		}
+		options.SlowQueryThreshold = threshold
+		return nil
+	}
+}
+
+// ExplainSlowQueries will configure the Client to report the plan of slow queries to its observer,
+// once per query fingerprint.
+func ExplainSlowQueries() Option {
+	return func(options *ClientOptions) error {
+		options.ExplainSlowQueries = true
+		return nil
+	}
+}
+
+// WithTracer will configure the Client to create a span with given tracer for every query, transaction,
+// commit and rollback.
+// If you use this option with WithNode, the tracer will be ignored.
+func WithTracer(tracer hooks.Tracer) Option {
+	return func(options *ClientOptions) error {
+		if tracer == nil {
+			return errors.New("makroud: a tracer is required")
+		}
+		options.Tracer = tracer
+		return nil
+	}
+}
+
+// WithMetrics will attach a metrics registry on Client.
+func WithMetrics(registry MetricsRegistry) Option {
+	return func(options *ClientOptions) error {
+		if registry == nil {
+			return errors.New("makroud: a metrics registry is required")
+		}
+		options.Metrics = registry
+		return nil
+	}
+}
+
+// WithCodec will register a field codec on Client with given name.
+// A field uses this codec with the "codec" tag, such as `mk:"column:email,codec:aes"`.
+func WithCodec(name string, codec Codec) Option {
+	return func(options *ClientOptions) error {
+		if name == "" {
+			return errors.New("makroud: a codec name is required")
+		}
+		if codec == nil {
+			return errors.New("makroud: a codec is required")
+		}
+		if options.Codecs == nil {
+			options.Codecs = map[string]Codec{}
+		}
+		options.Codecs[name] = codec
+		return nil
+	}
+}
+
+// WithPrimaryKeyGenerator will register a primary key generator on Client with given name.
+// A model uses this generator with the "pk" tag, such as `mk:"column:id,pk:snowflake"`.
+func WithPrimaryKeyGenerator(name string, generator PrimaryKeyGenerator) Option {
+	return func(options *ClientOptions) error {
+		if name == "" || name == TagKeyDB {
+			return errors.Errorf("makroud: invalid primary key generator name: '%s'", name)
+		}
+		if generator == nil {
+			return errors.New("makroud: a primary key generator is required")
+		}
+		if options.PrimaryKeyGenerators == nil {
+			options.PrimaryKeyGenerators = map[string]PrimaryKeyGenerator{}
+		}
+		options.PrimaryKeyGenerators[name] = generator
+		return nil
+	}
+}
+
+// WithClock will configure the Client to define created, updated and deleted keys with given clock, instead of
+// the database clock. These values are normalized to UTC and written back into the model.
+func WithClock(clock Clock) Option {
+	return func(options *ClientOptions) error {
+		if clock == nil {
+			return errors.New("makroud: a clock is required")
+		}
+		options.Clock = clock
+		return nil
+	}
+}
@@ -61,6 +61,8 @@ const (
 	PrimaryKeyUUIDV1Default
 	// PrimaryKeyUUIDV4Default uses a uuid v4 generator to define primary key value.
 	PrimaryKeyUUIDV4Default
+	// PrimaryKeyGeneratorDefault uses a generator registered on driver to define primary key value.
+	PrimaryKeyGeneratorDefault
 )
 
 func (e PrimaryKeyDefault) String() string {
@@ -73,6 +75,8 @@ func (e PrimaryKeyDefault) String() string {
 		return "uuid-v1"
 	case PrimaryKeyUUIDV4Default:
 		return "uuid-v4"
+	case PrimaryKeyGeneratorDefault:
+		return "generator"
 	default:
 		panic(fmt.Sprintf("makroud: unknown primary key default types: %d", e))
 	}
@@ -114,6 +118,9 @@ func NewPrimaryKey(field *Field) (*PrimaryKey, error) {
 		return nil, errors.Errorf("cannot use '%s' as primary key type", field.Type().String())
 	}
 
+	if field.PrimaryKeyGenerator() != "" {
+		pk.pkDefault = PrimaryKeyGeneratorDefault
+	}
 	if field.HasULID() {
 		pk.pkDefault = PrimaryKeyULIDDefault
 	}
@@ -147,16 +154,28 @@ func (key PrimaryKey) Value(model Model) (interface{}, error) {
 }
 
 // ValueOpt may returns the primary key's value, if defined.
+// The value is either an int64 or a string: for example, a uuid.UUID is returned using its text representation.
 func (key PrimaryKey) ValueOpt(model Model) (interface{}, bool) {
+	value, err := reflectx.GetFieldValueWithIndexes(reflectx.GetIndirectValue(model), key.FieldIndex())
+	if err != nil || reflectx.IsZero(value) {
+		value = nil
+	}
+
 	switch key.pkType {
 	case PKIntegerType:
-		id, err := reflectx.GetFieldValueInt64(model, key.FieldName())
+		if value == nil {
+			return int64(0), false
+		}
+		id, err := reflectx.ToInt64(value)
 		if err != nil || id == int64(0) {
 			return int64(0), false
 		}
 		return id, true
 	case PKStringType:
-		id, err := reflectx.GetFieldValueString(model, key.FieldName())
+		if value == nil {
+			return "", false
+		}
+		id, err := reflectx.ToString(value)
 		if err != nil || id == "" {
 			return "", false
 		}
@@ -166,8 +185,36 @@ func (key PrimaryKey) ValueOpt(model Model) (interface{}, bool) {
 	}
 }
 
+// toKeyValue converts given primary key or foreign key value to an int64 or a string, so it can be used by
+// the query builder. For example, an uuid.UUID is converted to its text representation,
+// and a named integer to an int64.
+func toKeyValue(field Field, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch reflectx.GetType(field.Type()) {
+	case reflectx.Int64Type:
+		return reflectx.ToInt64(value)
+	case reflectx.StringType:
+		return reflectx.ToString(value)
+	default:
+		return value, nil
+	}
+}
+
 // GenerateULID generates a new ulid.
+// With the default entropy source, ulid are strictly increasing within the same process.
 func GenerateULID(driver Driver) string {
+	entropy, ok := driver.Entropy().(*MonotonicEntropy)
+	if ok {
+		id, err := entropy.New()
+		if err != nil {
+			panic(err)
+		}
+		return id.String()
+	}
+
 	return ulid.MustNew(ulid.Now(), driver.Entropy()).String()
 }
 
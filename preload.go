@@ -8,6 +8,7 @@ import (
 	"github.com/ulule/loukoum/v3"
 	"github.com/ulule/loukoum/v3/builder"
 
+	"github.com/ulule/makroud/hooks"
 	"github.com/ulule/makroud/reflectx"
 )
 
@@ -36,6 +37,7 @@ func canPreload(rule preloadRule, dest interface{}) error {
 
 // Preload preloads related fields.
 func Preload(ctx context.Context, driver Driver, out interface{}, handlers ...PreloadHandler) error {
+	ctx = hooks.WithOperation(ctx, "Preload")
 	err := preload(ctx, driver, preloadRulePointerAndSlice, out, handlers...)
 	if err != nil {
 		return errors.Wrap(err, "makroud: cannot execute preload")
@@ -97,6 +99,7 @@ func WithPreloadField(field string) PreloadHandler {
 }
 
 // WithPreloadCallback returns a handler that preload a field with conditions.
+// A Scope, or a composition of them using Scopes, can be used as callback.
 func WithPreloadCallback(field string, callback func(query builder.Select) builder.Select) PreloadHandler {
 	return PreloadHandler{
 		field:    field,
@@ -105,7 +108,8 @@ func WithPreloadCallback(field string, callback func(query builder.Select) build
 	}
 }
 
-// WithUnscopedPreload unscopes given preload handler.
+// WithUnscopedPreload unscopes given preload handler:
+// the deleted key filter and the default scope of the preloaded model will be ignored.
 func WithUnscopedPreload(handler PreloadHandler) PreloadHandler {
 	handler.unscoped = true
 	return handler
@@ -338,9 +342,9 @@ func (handler *preloadHandler) preloadOneLocal(reference Reference, unscoped boo
 		return err
 	}
 
-	builder := callback(loukoum.Select(remote.Columns()).From(remote.TableName()))
-	if remote.HasDeletedKey() && !unscoped {
-		builder = builder.Where(loukoum.Condition(remote.DeletedKeyPath()).IsNull(true))
+	builder, err := handler.getPreloadQuery(remote, unscoped, callback)
+	if err != nil {
+		return err
 	}
 
 	switch local.ForeignKeyType() {
@@ -392,9 +396,9 @@ func (handler *preloadHandler) preloadOneRemote(reference Reference, unscoped bo
 		return err
 	}
 
-	builder := callback(loukoum.Select(remote.Columns()).From(remote.TableName()))
-	if remote.HasDeletedKey() && !unscoped {
-		builder = builder.Where(loukoum.Condition(remote.DeletedKeyPath()).IsNull(true))
+	builder, err := handler.getPreloadQuery(remote, unscoped, callback)
+	if err != nil {
+		return err
 	}
 
 	switch local.PrimaryKeyType() {
@@ -430,9 +434,9 @@ func (handler *preloadHandler) preloadMany(reference Reference, unscoped bool,
 		return err
 	}
 
-	builder := callback(loukoum.Select(remote.Columns()).From(remote.TableName()))
-	if remote.HasDeletedKey() && !unscoped {
-		builder = builder.Where(loukoum.Condition(remote.DeletedKeyPath()).IsNull(true))
+	builder, err := handler.getPreloadQuery(remote, unscoped, callback)
+	if err != nil {
+		return err
 	}
 
 	switch local.PrimaryKeyType() {
@@ -457,6 +461,23 @@ func (handler *preloadHandler) preloadMany(reference Reference, unscoped bool,
 	}
 }
 
+// getPreloadQuery returns the query used to fetch given remote reference.
+// Unless unscoped, the deleted key filter and the default scope of the remote reference are applied.
+// The tenant predicate of the remote reference, if any, is always applied.
+func (handler *preloadHandler) getPreloadQuery(remote ReferenceObject, unscoped bool,
+	callback func(query builder.Select) builder.Select) (builder.Select, error) {
+
+	query := callback(loukoum.Select(remote.Columns()).From(remote.TableName()))
+	if !unscoped {
+		if remote.HasDeletedKey() {
+			query = query.Where(loukoum.Condition(remote.DeletedKeyPath()).IsNull(true))
+		}
+		query = remote.DefaultScope(query)
+	}
+
+	return applyTenantScope(handler.ctx, handler.driver, remote.Schema(), query)
+}
+
 func getPreloadForEachCallbackRemoteString(preloader *reflectx.StringPreloader,
 	reference Reference) func(element reflectx.PreloadValue) error {
 
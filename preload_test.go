@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/ulule/loukoum/v3"
@@ -5756,3 +5757,62 @@ func TestPreload_Zootopia_MultiLevel(t *testing.T) {
 		}
 	})
 }
+
+func TestPreload_UUID(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		burrow := &Burrow{Name: "Bunnyburrow"}
+		err := makroud.Save(ctx, driver, burrow)
+		is.NoError(err)
+		is.NotEqual(uuid.Nil, burrow.ID)
+
+		bunnies := []*Bunny{
+			{Name: "Judy", BurrowID: burrow.ID},
+			{Name: "Bonnie", BurrowID: burrow.ID},
+		}
+		for _, bunny := range bunnies {
+			err = makroud.Save(ctx, driver, bunny)
+			is.NoError(err)
+			is.NotZero(bunny.ID)
+		}
+
+		bunny := &Bunny{}
+		err = makroud.Select(ctx, driver, bunny, loukoum.Condition("id").Equal(int32(bunnies[0].ID)))
+		is.NoError(err)
+		is.Equal(bunnies[0].ID, bunny.ID)
+		is.Equal(burrow.ID, bunny.BurrowID)
+
+		err = makroud.Preload(ctx, driver, bunny, makroud.WithPreloadField("Burrow"))
+		is.NoError(err)
+		is.NotNil(bunny.Burrow)
+		is.Equal(burrow.ID, bunny.Burrow.ID)
+		is.Equal(burrow.Name, bunny.Burrow.Name)
+
+		burrows := []Burrow{}
+		err = makroud.Select(ctx, driver, &burrows, loukoum.Condition("id").Equal(burrow.ID))
+		is.NoError(err)
+		is.Len(burrows, 1)
+
+		err = makroud.Preload(ctx, driver, &burrows, makroud.WithPreloadField("Bunnies"))
+		is.NoError(err)
+		is.Len(burrows[0].Bunnies, 2)
+
+		bunny.Name = "Judy Hopps"
+		err = makroud.Save(ctx, driver, bunny)
+		is.NoError(err)
+
+		err = makroud.Delete(ctx, driver, bunnies[1])
+		is.NoError(err)
+
+		count, err := makroud.Count(ctx, driver,
+			loukoum.Select(loukoum.Count("id")).From("ztp_bunny").Where(loukoum.Condition("name").Equal("Judy Hopps")))
+		is.NoError(err)
+		is.Equal(int64(1), count)
+
+		count, err = makroud.Count(ctx, driver, loukoum.Select(loukoum.Count("id")).From("ztp_bunny"))
+		is.NoError(err)
+		is.Equal(int64(1), count)
+	})
+}
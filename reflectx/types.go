@@ -3,6 +3,7 @@ package reflectx
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
 	"reflect"
 
 	"github.com/pkg/errors"
@@ -18,6 +19,8 @@ var (
 	stringType     = reflect.TypeOf("")
 	nullStringType = reflect.TypeOf(sql.NullString{})
 	scannerType    = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType     = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	marshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
 )
 
 // ----------------------------------------------------------------------------
@@ -33,7 +36,7 @@ func ToInt64(value interface{}) (int64, error) {
 	}
 
 	// For sql.NullInt64 and sql.NullFloat64 support.
-	valuer, ok := value.(driver.Valuer)
+	valuer, ok := getValuer(value)
 	if ok {
 		v, err := valuer.Value()
 		if err != nil {
@@ -75,8 +78,21 @@ func ToString(value interface{}) (string, error) {
 		return cast, nil
 	}
 
+	// uuid.UUID and ulid.ULID support, using their text representation.
+	reflected := reflect.Indirect(reflect.ValueOf(value))
+	if reflected.IsValid() && reflected.Kind() == reflect.Array {
+		marshaler, ok := reflected.Interface().(encoding.TextMarshaler)
+		if ok {
+			text, err := marshaler.MarshalText()
+			if err != nil {
+				return "", errors.Wrap(err, "cannot convert to string")
+			}
+			return string(text), nil
+		}
+	}
+
 	// sql.NullString support
-	valuer, ok := value.(driver.Valuer)
+	valuer, ok := getValuer(value)
 	if ok {
 		v, err := valuer.Value()
 		if err != nil {
@@ -88,7 +104,7 @@ func ToString(value interface{}) (string, error) {
 		value = v
 	}
 
-	reflected := reflect.Indirect(reflect.ValueOf(value))
+	reflected = reflect.Indirect(reflect.ValueOf(value))
 
 	if !reflected.IsValid() {
 		return "", errors.Errorf("invalid value: %v", value)
@@ -107,6 +123,28 @@ func ToOptionalString(value interface{}) (string, bool) {
 	return v, err == nil
 }
 
+// ----------------------------------------------------------------------------
+// Valuer
+// ----------------------------------------------------------------------------
+
+// getValuer returns given value as a driver.Valuer, even if it's only implemented with a pointer receiver.
+func getValuer(value interface{}) (driver.Valuer, bool) {
+	valuer, ok := value.(driver.Valuer)
+	if ok || value == nil {
+		return valuer, ok
+	}
+
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Ptr || !reflect.PtrTo(reflected.Type()).Implements(valuerType) {
+		return nil, false
+	}
+
+	pointer := reflect.New(reflected.Type())
+	pointer.Elem().Set(reflected)
+
+	return pointer.Interface().(driver.Valuer), true
+}
+
 // ----------------------------------------------------------------------------
 // Scanner
 // ----------------------------------------------------------------------------
@@ -169,5 +207,32 @@ func GetType(value reflect.Type) Type {
 			return OptionalInt64Type
 		}
 	}
+
+	// Other types must be scanned back from their column.
+	pointer := reflect.PtrTo(indirect)
+	if !pointer.Implements(scannerType) {
+		return UnsupportedType
+	}
+
+	// For uuid.UUID and ulid.ULID support.
+	if indirect.Kind() == reflect.Array && indirect.Implements(marshalerType) {
+		return StringType
+	}
+
+	// For a custom driver.Valuer, the type is inferred from the value returned by its zero value.
+	// The pointer is used since it also has the methods defined with a value receiver.
+	if pointer.Implements(valuerType) {
+		value, err := reflect.New(indirect).Interface().(driver.Valuer).Value()
+		if err != nil {
+			return UnsupportedType
+		}
+		switch value.(type) {
+		case int64:
+			return Int64Type
+		case string:
+			return StringType
+		}
+	}
+
 	return UnsupportedType
 }
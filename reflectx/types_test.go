@@ -2,11 +2,14 @@ package reflectx_test
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/gofrs/uuid"
+	"github.com/oklog/ulid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ulule/makroud/reflectx"
@@ -29,6 +32,8 @@ func TestReflectx_ToInt64(t *testing.T) {
 	f64 := float64(1)
 	ni64 := sql.NullInt64{Valid: true, Int64: 1}
 	nf64 := sql.NullFloat64{Valid: true, Float64: float64(1)}
+	aid := AccountID{value: 1}
+	bid := BadgeID{value: 1}
 
 	valids := []interface{}{
 		i,
@@ -59,6 +64,10 @@ func TestReflectx_ToInt64(t *testing.T) {
 		&ni64,
 		nf64,
 		&nf64,
+		aid,
+		&aid,
+		bid,
+		&bid,
 	}
 
 	for i, valid := range valids {
@@ -110,12 +119,59 @@ func TestReflectx_ToInt64(t *testing.T) {
 	}
 }
 
+type UserID int64
+
+type AccountID struct {
+	value int64
+}
+
+func (id AccountID) Value() (driver.Value, error) {
+	return id.value, nil
+}
+
+func (id *AccountID) Scan(src interface{}) error {
+	value, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("cannot scan %T", src)
+	}
+	id.value = value
+	return nil
+}
+
+type BadgeID struct {
+	value int64
+}
+
+func (id *BadgeID) Value() (driver.Value, error) {
+	return id.value, nil
+}
+
+func (id *BadgeID) Scan(src interface{}) error {
+	value, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("cannot scan %T", src)
+	}
+	id.value = value
+	return nil
+}
+
+type TokenID struct {
+	value string
+}
+
+func (id TokenID) Value() (driver.Value, error) {
+	return id.value, nil
+}
+
 func TestReflectx_ToString(t *testing.T) {
 	is := require.New(t)
 
+	type Letter string
+
 	str := "c"
 	run := 'c'
 	ns := sql.NullString{Valid: true, String: "c"}
+	ltr := Letter("c")
 
 	valids := []interface{}{
 		str,
@@ -124,6 +180,8 @@ func TestReflectx_ToString(t *testing.T) {
 		&run,
 		ns,
 		&ns,
+		ltr,
+		&ltr,
 	}
 
 	for i, valid := range valids {
@@ -166,6 +224,21 @@ func TestReflectx_ToString(t *testing.T) {
 		is.False(ok, m)
 		is.Equal("", v, m)
 	}
+
+	id1 := uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	id2 := ulid.MustParse("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+
+	v, err := reflectx.ToString(id1)
+	is.NoError(err)
+	is.Equal("6ba7b810-9dad-11d1-80b4-00c04fd430c8", v)
+
+	v, err = reflectx.ToString(&id1)
+	is.NoError(err)
+	is.Equal("6ba7b810-9dad-11d1-80b4-00c04fd430c8", v)
+
+	v, err = reflectx.ToString(id2)
+	is.NoError(err)
+	is.Equal("01ARZ3NDEKTSV4RRFFQ69G5FAV", v)
 }
 
 func TestReflectx_GetType(t *testing.T) {
@@ -194,6 +267,12 @@ func TestReflectx_GetType(t *testing.T) {
 	x := true
 	e := foo{value: 3}
 	t0 := time.Now()
+	uid := UserID(1)
+	aid := AccountID{value: 1}
+	bid := BadgeID{value: 1}
+	tid := TokenID{value: "c"}
+	id1 := uuid.Nil
+	id2 := ulid.ULID{}
 
 	scenarios := []struct {
 		input    reflect.Type
@@ -351,6 +430,38 @@ func TestReflectx_GetType(t *testing.T) {
 			input:    reflect.TypeOf(&t0),
 			expected: reflectx.UnsupportedType,
 		},
+		{
+			input:    reflect.TypeOf(uid),
+			expected: reflectx.Int64Type,
+		},
+		{
+			input:    reflect.TypeOf(aid),
+			expected: reflectx.Int64Type,
+		},
+		{
+			input:    reflect.TypeOf(bid),
+			expected: reflectx.Int64Type,
+		},
+		{
+			input:    reflect.TypeOf(&bid),
+			expected: reflectx.Int64Type,
+		},
+		{
+			input:    reflect.TypeOf(tid),
+			expected: reflectx.UnsupportedType,
+		},
+		{
+			input:    reflect.TypeOf(id1),
+			expected: reflectx.StringType,
+		},
+		{
+			input:    reflect.TypeOf(&id1),
+			expected: reflectx.StringType,
+		},
+		{
+			input:    reflect.TypeOf(id2),
+			expected: reflectx.StringType,
+		},
 	}
 
 	for i, scenario := range scenarios {
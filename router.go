@@ -0,0 +1,473 @@
+package makroud
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RouterBalancer defines the strategy used by a router to pick a replica.
+type RouterBalancer int
+
+const (
+	// RoundRobinBalancer picks replicas one after another.
+	RoundRobinBalancer RouterBalancer = iota
+	// LeastConnectionsBalancer picks the replica with the fewest queries in progress.
+	LeastConnectionsBalancer
+)
+
+// RouterOptions configure a Router instance.
+type RouterOptions struct {
+	Master               string
+	Replicas             []string
+	Balancer             RouterBalancer
+	ReadYourWritesWindow time.Duration
+}
+
+// NewRouterOptions creates a new RouterOptions instance with default options.
+func NewRouterOptions() *RouterOptions {
+	return &RouterOptions{
+		Master:               MasterSelector,
+		Replicas:             []string{SlaveSelector},
+		Balancer:             RoundRobinBalancer,
+		ReadYourWritesWindow: 1 * time.Second,
+	}
+}
+
+// RouterOption is used to define Router configuration.
+type RouterOption func(*RouterOptions) error
+
+// RouterMaster will configure the Router to use the given alias as master.
+func RouterMaster(alias string) RouterOption {
+	return func(options *RouterOptions) error {
+		if alias == "" {
+			return errors.New("makroud: a master alias is required")
+		}
+		options.Master = alias
+		return nil
+	}
+}
+
+// RouterReplicas will configure the Router to use the given aliases as replicas.
+func RouterReplicas(aliases ...string) RouterOption {
+	return func(options *RouterOptions) error {
+		options.Replicas = aliases
+		return nil
+	}
+}
+
+// RoundRobin will configure the Router to balance reads between replicas one after another.
+func RoundRobin() RouterOption {
+	return func(options *RouterOptions) error {
+		options.Balancer = RoundRobinBalancer
+		return nil
+	}
+}
+
+// LeastConnections will configure the Router to balance reads on the replica with the fewest queries in progress.
+func LeastConnections() RouterOption {
+	return func(options *RouterOptions) error {
+		options.Balancer = LeastConnectionsBalancer
+		return nil
+	}
+}
+
+// ReadYourWritesWindow will configure the Router to send reads to master during the given duration
+// after a write, if the context has been created with WithReadYourWrites.
+func ReadYourWritesWindow(window time.Duration) RouterOption {
+	return func(options *RouterOptions) error {
+		if window < 0 {
+			return errors.New("makroud: read your writes window must be positive")
+		}
+		options.ReadYourWritesWindow = window
+		return nil
+	}
+}
+
+// writesKey is the context key used to store the last write of a session.
+type writesKey struct{}
+
+// routerSession keeps track of the last write executed with a context.
+type routerSession struct {
+	last int64
+}
+
+// WithReadYourWrites returns a copy of given context that keeps track of writes executed by a Router.
+// For a short window after a write, reads using this context are sent to master,
+// so they can't observe a replica lagging behind.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, writesKey{}, &routerSession{})
+}
+
+// routerReplica is a replica alias with its number of queries in progress.
+type routerReplica struct {
+	alias    string
+	inflight int64
+}
+
+// Router is a driver using a Selector to send writes and transactions to master,
+// and reads to a pool of replicas. It's an implementation of Driver.
+type Router struct {
+	selector *Selector
+	master   Driver
+	replicas []*routerReplica
+	balancer RouterBalancer
+	window   time.Duration
+	counter  uint64
+}
+
+// NewRouter returns a new Router instance using given selector.
+func NewRouter(selector *Selector, options ...RouterOption) (*Router, error) {
+	opts := NewRouterOptions()
+
+	for _, option := range options {
+		err := option(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewRouterWithOptions(selector, opts)
+}
+
+// NewRouterWithOptions returns a new Router instance using given selector.
+func NewRouterWithOptions(selector *Selector, options *RouterOptions) (*Router, error) {
+	master, err := selector.Using(options.Master)
+	if err != nil {
+		return nil, errors.Wrap(err, "makroud: cannot create router")
+	}
+
+	replicas := make([]*routerReplica, 0, len(options.Replicas))
+	for _, alias := range options.Replicas {
+		replicas = append(replicas, &routerReplica{
+			alias: alias,
+		})
+	}
+
+	router := &Router{
+		selector: selector,
+		master:   master,
+		replicas: replicas,
+		balancer: options.Balancer,
+		window:   options.ReadYourWritesWindow,
+	}
+
+	return router, nil
+}
+
+// Master returns the driver used for writes and transactions.
+func (r *Router) Master() Driver {
+	return r.master
+}
+
+// Reader returns the driver that would be used for a read with given context.
+func (r *Router) Reader(ctx context.Context) Driver {
+	driver, replica := r.read(ctx)
+	if replica != nil {
+		replica.release()
+	}
+	return driver
+}
+
+// Exec executes a statement using given arguments.
+func (r *Router) Exec(ctx context.Context, query string, args ...interface{}) error {
+	r.write(ctx)
+	return r.master.Exec(ctx, query, args...)
+}
+
+// MustExec executes a statement using given arguments.
+// If an error has occurred, it panics.
+func (r *Router) MustExec(ctx context.Context, query string, args ...interface{}) {
+	err := r.Exec(ctx, query, args...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Query executes a statement that returns rows using given arguments.
+func (r *Router) Query(ctx context.Context, query string, args ...interface{}) (Rows, error) {
+	if !isReadQuery(query) {
+		r.write(ctx)
+		return r.master.Query(ctx, query, args...)
+	}
+
+	driver, replica := r.read(ctx)
+	if replica == nil {
+		return driver.Query(ctx, query, args...)
+	}
+
+	rows, err := driver.Query(ctx, query, args...)
+	if err != nil {
+		replica.release()
+		return nil, err
+	}
+
+	return &routerRows{Rows: rows, release: replica.release}, nil
+}
+
+// QueryRow executes a statement returning a single row.
+func (r *Router) QueryRow(ctx context.Context, query string, args ...interface{}) (Row, error) {
+	if !isReadQuery(query) {
+		r.write(ctx)
+		return r.master.QueryRow(ctx, query, args...)
+	}
+
+	driver, replica := r.read(ctx)
+	if replica == nil {
+		return driver.QueryRow(ctx, query, args...)
+	}
+
+	row, err := driver.QueryRow(ctx, query, args...)
+	if err != nil {
+		replica.release()
+		return nil, err
+	}
+
+	return &routerRow{Row: row, release: replica.release}, nil
+}
+
+// MustQuery executes a statement that returns rows using given arguments.
+// If an error has occurred, it panics.
+func (r *Router) MustQuery(ctx context.Context, query string, args ...interface{}) Rows {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		panic(err)
+	}
+	return rows
+}
+
+// Prepare creates a prepared statement for later queries or executions.
+// The statement is always prepared on master.
+func (r *Router) Prepare(ctx context.Context, query string) (Statement, error) {
+	return r.master.Prepare(ctx, query)
+}
+
+// Begin starts a new transaction on master.
+// Every query executed with the returned driver will be sent to master.
+func (r *Router) Begin(ctx context.Context, opts ...*TxOptions) (Driver, error) {
+	r.write(ctx)
+	return r.master.Begin(ctx, opts...)
+}
+
+// Rollback rollbacks the associated transaction.
+func (r *Router) Rollback() error {
+	return r.master.Rollback()
+}
+
+// Commit commits the associated transaction.
+func (r *Router) Commit() error {
+	return r.master.Commit()
+}
+
+// Close closes every drivers connections of the underlying selector.
+func (r *Router) Close() error {
+	return r.selector.Close()
+}
+
+// Ping verifies that the master connection is healthy.
+func (r *Router) Ping() error {
+	return r.master.Ping()
+}
+
+// DriverName returns the driver name used by this driver.
+func (r *Router) DriverName() string {
+	return r.master.DriverName()
+}
+
+// HasCache returns if current driver has an internal cache.
+func (r *Router) HasCache() bool {
+	return r.master.HasCache()
+}
+
+// GetCache returns the driver internal cache.
+//
+// WARNING: Please, do not use this method unless you know what you are doing:
+// YOU COULD BREAK YOUR DRIVER.
+func (r *Router) GetCache() *DriverCache {
+	return r.master.GetCache()
+}
+
+// SetCache replace the driver internal cache by the given one.
+//
+// WARNING: Please, do not use this method unless you know what you are doing:
+// YOU COULD BREAK YOUR DRIVER.
+func (r *Router) SetCache(cache *DriverCache) {
+	r.master.SetCache(cache)
+}
+
+// HasLogger returns if the driver has a logger.
+func (r *Router) HasLogger() bool {
+	return r.master.HasLogger()
+}
+
+// Logger returns the driver logger.
+//
+// WARNING: Please, do not use this method unless you know what you are doing.
+func (r *Router) Logger() Logger {
+	return r.master.Logger()
+}
+
+// HasObserver returns if the driver has an observer.
+func (r *Router) HasObserver() bool {
+	return r.master.HasObserver()
+}
+
+// Observer returns the driver observer.
+//
+// WARNING: Please, do not use this method unless you know what you are doing.
+func (r *Router) Observer() Observer {
+	return r.master.Observer()
+}
+
+// Entropy returns an entropy source, used for primary key generation (if required).
+//
+// WARNING: Please, do not use this method unless you know what you are doing.
+func (r *Router) Entropy() io.Reader {
+	return r.master.Entropy()
+}
+
+// client returns the Client backing master, if any.
+func (r *Router) client() *Client {
+	return getClient(r.master)
+}
+
+// write records a write for given context, if it keeps track of them.
+func (r *Router) write(ctx context.Context) {
+	session, ok := ctx.Value(writesKey{}).(*routerSession)
+	if ok {
+		atomic.StoreInt64(&session.last, time.Now().UnixNano())
+	}
+}
+
+// pinned returns if reads with given context must be sent to master.
+func (r *Router) pinned(ctx context.Context) bool {
+	session, ok := ctx.Value(writesKey{}).(*routerSession)
+	if !ok {
+		return false
+	}
+
+	last := atomic.LoadInt64(&session.last)
+	if last == 0 {
+		return false
+	}
+
+	return time.Since(time.Unix(0, last)) < r.window
+}
+
+// read returns the driver to use for a read with given context.
+// If a replica is returned, it must be released once the query is done.
+func (r *Router) read(ctx context.Context) (Driver, *routerReplica) {
+	if len(r.replicas) == 0 || r.pinned(ctx) {
+		return r.master, nil
+	}
+
+	for _, replica := range r.candidates() {
+		if !r.selector.IsHealthy(replica.alias) {
+			continue
+		}
+
+		driver, err := r.selector.Using(replica.alias)
+		if err != nil {
+			continue
+		}
+
+		atomic.AddInt64(&replica.inflight, 1)
+		return driver, replica
+	}
+
+	return r.master, nil
+}
+
+// candidates returns replicas sorted by preference, according to the balancer.
+func (r *Router) candidates() []*routerReplica {
+	length := len(r.replicas)
+	offset := 0
+
+	switch r.balancer {
+	case LeastConnectionsBalancer:
+		minimum := atomic.LoadInt64(&r.replicas[0].inflight)
+		for i := 1; i < length; i++ {
+			inflight := atomic.LoadInt64(&r.replicas[i].inflight)
+			if inflight < minimum {
+				minimum = inflight
+				offset = i
+			}
+		}
+	default:
+		offset = int((atomic.AddUint64(&r.counter, 1) - 1) % uint64(length))
+	}
+
+	candidates := make([]*routerReplica, 0, length)
+	for i := 0; i < length; i++ {
+		candidates = append(candidates, r.replicas[(offset+i)%length])
+	}
+
+	return candidates
+}
+
+// release decrements the number of queries in progress on this replica.
+func (replica *routerReplica) release() {
+	atomic.AddInt64(&replica.inflight, -1)
+}
+
+// isReadQuery returns if given query can be executed on a replica.
+func isReadQuery(query string) bool {
+	query = strings.ToUpper(strings.TrimSpace(query))
+	if !strings.HasPrefix(query, "SELECT") {
+		return false
+	}
+
+	for _, clause := range []string{"FOR UPDATE", "FOR NO KEY UPDATE", "FOR SHARE", "FOR KEY SHARE"} {
+		if strings.Contains(query, clause) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// A routerRows releases its replica once closed or consumed.
+type routerRows struct {
+	Rows
+	once    sync.Once
+	release func()
+}
+
+// Next prepares the next result row for reading with the Scan method.
+func (r *routerRows) Next() bool {
+	next := r.Rows.Next()
+	if !next {
+		r.once.Do(r.release)
+	}
+	return next
+}
+
+// Close closes the Rows, preventing further enumeration/iteration.
+func (r *routerRows) Close() error {
+	r.once.Do(r.release)
+	return r.Rows.Close()
+}
+
+// A routerRow releases its replica once scanned.
+type routerRow struct {
+	Row
+	once    sync.Once
+	release func()
+}
+
+// Write copies the columns in the current row into the given map.
+func (r *routerRow) Write(dest map[string]interface{}) error {
+	defer r.once.Do(r.release)
+	return r.Row.Write(dest)
+}
+
+// Scan copies the columns in the current row into the values pointed at by dest.
+func (r *routerRow) Scan(dest ...interface{}) error {
+	defer r.once.Do(r.release)
+	return r.Row.Scan(dest...)
+}
@@ -0,0 +1,159 @@
+package makroud_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulule/loukoum/v3"
+
+	"github.com/ulule/makroud"
+	"github.com/ulule/makroud/makroudtest"
+)
+
+func TestRouter_Routing(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		replica, err := makroud.New(Options()...)
+		is.NoError(err)
+
+		selector, err := makroud.NewSelectorWithDrivers(map[string]makroud.Driver{
+			makroud.MasterSelector: driver,
+			makroud.SlaveSelector:  replica,
+		})
+		is.NoError(err)
+
+		router, err := makroud.NewRouter(selector)
+		is.NoError(err)
+		is.Equal(driver, router.Master())
+		is.Equal(replica, router.Reader(ctx))
+
+		human := &Human{Name: "Gerlinde"}
+		err = makroud.Save(ctx, router, human)
+		is.NoError(err)
+		is.NotZero(human.ID)
+
+		result := &Human{}
+		err = makroud.Select(ctx, router, result, loukoum.Condition("id").Equal(human.ID))
+		is.NoError(err)
+		is.Equal(human.Name, result.Name)
+
+		tx, err := router.Begin(ctx)
+		is.NoError(err)
+		is.NotEqual(router, tx)
+		is.NoError(tx.Rollback())
+
+		ctx = makroud.WithReadYourWrites(ctx)
+		is.Equal(replica, router.Reader(ctx))
+
+		human.Name = "Siegrun"
+		err = makroud.Save(ctx, router, human)
+		is.NoError(err)
+		is.Equal(driver, router.Reader(ctx))
+
+		router, err = makroud.NewRouter(selector, makroud.ReadYourWritesWindow(10*time.Millisecond))
+		is.NoError(err)
+
+		err = makroud.Save(ctx, router, human)
+		is.NoError(err)
+		is.Equal(driver, router.Reader(ctx))
+
+		time.Sleep(20 * time.Millisecond)
+		is.Equal(replica, router.Reader(ctx))
+
+		is.NoError(replica.Close())
+	})
+}
+
+func TestRouter_Balancer(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		replica1, err := makroud.New(Options()...)
+		is.NoError(err)
+
+		replica2, err := makroud.New(Options()...)
+		is.NoError(err)
+
+		selector, err := makroud.NewSelectorWithDrivers(map[string]makroud.Driver{
+			"primary":   driver,
+			"replica-1": replica1,
+			"replica-2": replica2,
+		})
+		is.NoError(err)
+
+		router, err := makroud.NewRouter(selector,
+			makroud.RouterMaster("primary"),
+			makroud.RouterReplicas("replica-1", "replica-2", "replica-3"),
+		)
+		is.NoError(err)
+
+		is.Equal(replica1, router.Reader(ctx))
+		is.Equal(replica2, router.Reader(ctx))
+		is.Equal(replica1, router.Reader(ctx))
+
+		router, err = makroud.NewRouter(selector,
+			makroud.RouterMaster("primary"),
+			makroud.RouterReplicas("replica-1", "replica-2"),
+			makroud.LeastConnections(),
+		)
+		is.NoError(err)
+
+		rows, err := router.Query(ctx, "SELECT 1")
+		is.NoError(err)
+		is.Equal(replica2, router.Reader(ctx))
+		is.NoError(rows.Close())
+
+		is.NoError(replica1.Close())
+		is.NoError(replica2.Close())
+	})
+}
+
+func TestRouter_SlowQuery(t *testing.T) {
+	ctx := makroud.WithReadYourWrites(context.Background())
+	is := require.New(t)
+
+	observer := &testObserver{}
+	options := []makroud.Option{
+		makroud.WithObserver(observer),
+		makroud.SlowQueryThreshold(time.Nanosecond),
+		makroud.ExplainSlowQueries(),
+	}
+
+	master, err := makroudtest.New(options...)
+	is.NoError(err)
+
+	replica, err := makroudtest.New(options...)
+	is.NoError(err)
+
+	selector, err := makroud.NewSelectorWithDrivers(map[string]makroud.Driver{
+		makroud.MasterSelector: master,
+		makroud.SlaveSelector:  replica,
+	})
+	is.NoError(err)
+
+	router, err := makroud.NewRouter(selector)
+	is.NoError(err)
+
+	replica.Return(`^SELECT count`, makroudtest.NewRows("count").AddRow(3))
+	replica.Return(`^EXPLAIN`, makroudtest.NewRows("QUERY PLAN").AddRow(`[{"Plan": {"Node Type": "Result"}}]`))
+
+	count := int64(0)
+	err = makroud.RawExec(ctx, router, `SELECT count(*) FROM ztp_human`, &count)
+	is.NoError(err)
+	is.Equal(int64(3), count)
+
+	is.True(replica.AssertQuery(t, `^EXPLAIN \(FORMAT JSON\) SELECT count\(\*\) FROM ztp_human$`))
+	is.True(replica.AssertScriptsConsumed(t))
+	is.Empty(master.Calls())
+	is.Equal(makroud.Driver(replica), router.Reader(ctx))
+
+	events := observer.Events("OnSlowQuery")
+	is.Len(events, 1)
+	is.Equal(`SELECT count(*) FROM ztp_human`, events[0].flags["query"])
+	is.Contains(events[0].flags["plan"], `"Node Type"`)
+}
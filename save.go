@@ -6,12 +6,15 @@ import (
 	"github.com/pkg/errors"
 	"github.com/ulule/loukoum/v3"
 	"github.com/ulule/loukoum/v3/builder"
+	"github.com/ulule/loukoum/v3/stmt"
 
+	"github.com/ulule/makroud/hooks"
 	"github.com/ulule/makroud/reflectx"
 )
 
 // Save inserts or updates the given instance.
 func Save(ctx context.Context, driver Driver, model Model) error {
+	ctx = hooks.WithOperation(ctx, "Save")
 	err := save(ctx, driver, model)
 	if err != nil {
 		return errors.Wrap(err, "makroud: cannot execute save")
@@ -35,12 +38,17 @@ func save(ctx context.Context, driver Driver, model Model) error {
 	pk := schema.PrimaryKey()
 	id, hasPK := pk.ValueOpt(model)
 
-	err = generateSaveQuery(schema, model, hasPK, &returning, values)
+	err = generateSaveQuery(driver, schema, model, hasPK, &returning, values)
 	if err != nil {
 		return err
 	}
 
-	builder, err := getSaveBuilder(driver, model, pk, hasPK, id, &returning, values)
+	tenant, err := generateTenantSaveQuery(ctx, driver, schema, hasPK, &returning, values)
+	if err != nil {
+		return errors.Wrapf(err, "%T cannot be saved", model)
+	}
+
+	builder, err := getSaveBuilder(driver, model, pk, hasPK, id, tenant, &returning, values)
 	if err != nil {
 		return err
 	}
@@ -55,7 +63,11 @@ func save(ctx context.Context, driver Driver, model Model) error {
 	return err
 }
 
-func generateSaveQuery(schema *Schema, model Model, hasPK bool, returning *[]string, values loukoum.Map) error {
+func generateSaveQuery(driver Driver, schema *Schema, model Model, hasPK bool,
+	returning *[]string, values loukoum.Map) error {
+
+	now, hasClock := getClockTime(driver)
+
 	instance := reflectx.GetIndirectValue(model)
 	for _, column := range schema.fields {
 		if column.IsPrimaryKey() {
@@ -68,7 +80,15 @@ func generateSaveQuery(schema *Schema, model Model, hasPK bool, returning *[]str
 			return err
 		}
 
-		if !hasPK && column.HasDefault() && reflectx.IsZero(value) {
+		if hasClock && isClockKey(column, hasPK, value) {
+
+			err = setClockValue(model, column, now)
+			if err != nil {
+				return err
+			}
+			values[name] = now
+
+		} else if !hasPK && column.HasDefault() && reflectx.IsZero(value) {
 
 			(*returning) = append((*returning), name)
 
@@ -77,6 +97,34 @@ func generateSaveQuery(schema *Schema, model Model, hasPK bool, returning *[]str
 			values[name] = loukoum.Raw("NOW()")
 			(*returning) = append((*returning), name)
 
+		} else if column.HasCodec() {
+
+			values[name], err = encodeFieldValue(driver, column, value)
+			if err != nil {
+				return err
+			}
+
+		} else if column.IsJSON() {
+
+			values[name], err = toJSONValue(value)
+			if err != nil {
+				return errors.Wrapf(err, "cannot marshal %s.%s as JSON", schema.ModelName(), column.FieldName())
+			}
+
+		} else if column.IsArray() {
+
+			values[name], err = toArrayValue(value)
+			if err != nil {
+				return errors.Wrapf(err, "cannot use %s.%s as array", schema.ModelName(), column.FieldName())
+			}
+
+		} else if column.IsForeignKey() {
+
+			values[name], err = toKeyValue(column, value)
+			if err != nil {
+				return errors.Wrapf(err, "cannot use %s.%s as foreign key", schema.ModelName(), column.FieldName())
+			}
+
 		} else {
 
 			values[name] = value
@@ -87,33 +135,51 @@ func generateSaveQuery(schema *Schema, model Model, hasPK bool, returning *[]str
 	return nil
 }
 
-func getSaveBuilder(driver Driver, model Model, pk PrimaryKey,
-	hasPK bool, id interface{}, returning *[]string, values loukoum.Map) (builder.Builder, error) {
+// generateTenantSaveQuery defines the tenant column value on insert, or returns the tenant predicate on update.
+// On both cases, the tenant column is returned so the model is updated.
+func generateTenantSaveQuery(ctx context.Context, driver Driver, schema *Schema, hasPK bool,
+	returning *[]string, values loukoum.Map) (stmt.Expression, error) {
+
+	field, tenant, ok, err := getTenantValue(ctx, driver, schema)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	name := field.ColumnName()
+
+	found := false
+	for i := range *returning {
+		if (*returning)[i] == name {
+			found = true
+		}
+	}
+	if !found {
+		(*returning) = append((*returning), name)
+	}
 
 	if !hasPK {
-		switch pk.Default() {
-		case PrimaryKeyDBDefault:
-			(*returning) = append((*returning), pk.ColumnName())
-
-		case PrimaryKeyULIDDefault:
-			ulid := GenerateULID(driver)
-			values[pk.ColumnName()] = ulid
-			(*returning) = append((*returning), pk.ColumnName())
-
-		case PrimaryKeyUUIDV1Default:
-			uuid := GenerateUUIDV1(driver)
-			values[pk.ColumnName()] = uuid
-			(*returning) = append((*returning), pk.ColumnName())
-
-		case PrimaryKeyUUIDV4Default:
-			uuid := GenerateUUIDV4(driver)
-			values[pk.ColumnName()] = uuid
-			(*returning) = append((*returning), pk.ColumnName())
-
-		default:
-			return nil, errors.Errorf("unsupported primary key type: %s", pk.Default())
+		values[name] = tenant
+		return nil, nil
+	}
+
+	delete(values, name)
+	return loukoum.Condition(name).Equal(tenant), nil
+}
+
+func getSaveBuilder(driver Driver, model Model, pk PrimaryKey, hasPK bool, id interface{},
+	tenant stmt.Expression, returning *[]string, values loukoum.Map) (builder.Builder, error) {
+
+	if !hasPK {
+		if pk.Default() != PrimaryKeyDBDefault {
+			value, err := generatePrimaryKey(driver, pk)
+			if err != nil {
+				return nil, err
+			}
+			values[pk.ColumnName()] = value
 		}
 
+		(*returning) = append((*returning), pk.ColumnName())
+
 		builder := loukoum.Insert(model.TableName()).
 			Set(values).
 			Returning((*returning))
@@ -126,5 +192,9 @@ func getSaveBuilder(driver Driver, model Model, pk PrimaryKey,
 		Where(loukoum.Condition(pk.ColumnName()).Equal(id)).
 		Returning((*returning))
 
+	if tenant != nil {
+		builder = builder.And(tenant)
+	}
+
 	return builder, nil
 }
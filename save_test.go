@@ -124,3 +124,182 @@ func TestSave_Meow(t *testing.T) {
 
 	})
 }
+
+func TestSave_JSON(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		schema, err := makroud.GetSchema(driver, &Kiosk{})
+		is.NoError(err)
+		is.True(schema.HasColumn("hours"))
+		is.True(schema.HasColumn("manager"))
+
+		kiosk := &Kiosk{
+			Name: "Tundratown",
+			Hours: map[string]string{
+				"monday":  "08:00-18:00",
+				"tuesday": "08:00-12:00",
+			},
+			Manager: &Location{Street: "3 Ice Road", City: "Zootopia"},
+		}
+
+		err = makroud.Save(ctx, driver, kiosk)
+		is.NoError(err)
+		is.NotZero(kiosk.ID)
+
+		result := &Kiosk{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(kiosk.ID))
+		is.NoError(err)
+		is.Equal(kiosk.Hours, result.Hours)
+		is.NotNil(result.Manager)
+		is.Equal(*kiosk.Manager, *result.Manager)
+
+		hours := ""
+		err = makroud.RawExecArgs(ctx, driver, "SELECT hours->>'monday' FROM ztp_kiosk WHERE id = $1",
+			[]interface{}{kiosk.ID}, &hours)
+		is.NoError(err)
+		is.Equal("08:00-18:00", hours)
+
+		type Schedule struct {
+			Name    string            `mk:"name"`
+			Hours   map[string]string `mk:"hours,json"`
+			Manager *Location         `mk:"manager,json"`
+		}
+
+		schedule := &Schedule{}
+		err = makroud.RawExecArgs(ctx, driver, "SELECT name, hours, manager FROM ztp_kiosk WHERE id = $1",
+			[]interface{}{kiosk.ID}, schedule)
+		is.NoError(err)
+		is.Equal(kiosk.Name, schedule.Name)
+		is.Equal(kiosk.Hours, schedule.Hours)
+		is.NotNil(schedule.Manager)
+		is.Equal(*kiosk.Manager, *schedule.Manager)
+
+		kiosk.Hours = nil
+		kiosk.Manager = nil
+
+		err = makroud.Save(ctx, driver, kiosk)
+		is.NoError(err)
+
+		count, err := makroud.Count(ctx, driver,
+			loukoum.Select(loukoum.Count("id")).From("ztp_kiosk").
+				Where(loukoum.Condition("hours").IsNull(true)).
+				And(loukoum.Condition("manager").IsNull(true)))
+		is.NoError(err)
+		is.Equal(int64(1), count)
+
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(kiosk.ID))
+		is.NoError(err)
+		is.Nil(result.Hours)
+		is.Nil(result.Manager)
+	})
+}
+
+func TestSave_Array(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		schema, err := makroud.GetSchema(driver, &Kiosk{})
+		is.NoError(err)
+		is.True(schema.HasColumn("tags"))
+		is.True(schema.HasColumn("sizes"))
+
+		kiosk := &Kiosk{
+			Name:  "Rainforest District",
+			Tags:  []string{"coffee", "popsicle"},
+			Sizes: []int16{1, 2, 3},
+		}
+
+		err = makroud.Save(ctx, driver, kiosk)
+		is.NoError(err)
+		is.NotZero(kiosk.ID)
+
+		result := &Kiosk{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(kiosk.ID))
+		is.NoError(err)
+		is.Equal(kiosk.Tags, result.Tags)
+		is.Equal(kiosk.Sizes, result.Sizes)
+
+		count, err := makroud.Count(ctx, driver,
+			loukoum.Select(loukoum.Count("id")).From("ztp_kiosk").
+				Where(loukoum.Raw("'popsicle' = ANY(tags)")))
+		is.NoError(err)
+		is.Equal(int64(1), count)
+
+		type Menu struct {
+			Name string   `mk:"name"`
+			Tags []string `mk:"tags"`
+		}
+
+		menu := &Menu{}
+		err = makroud.RawExec(ctx, driver, "SELECT name, tags FROM ztp_kiosk", menu)
+		is.NoError(err)
+		is.Equal(kiosk.Tags, menu.Tags)
+
+		type Sizing struct {
+			Sizes []int8 `mk:"sizes,array"`
+		}
+
+		sizing := &Sizing{}
+		err = makroud.RawExecArgs(ctx, driver, "SELECT sizes FROM ztp_kiosk WHERE id = $1",
+			[]interface{}{kiosk.ID}, sizing)
+		is.NoError(err)
+		is.Equal([]int8{1, 2, 3}, sizing.Sizes)
+
+		kiosk.Sizes = []int16{1, 300}
+
+		err = makroud.Save(ctx, driver, kiosk)
+		is.NoError(err)
+
+		err = makroud.RawExecArgs(ctx, driver, "SELECT sizes FROM ztp_kiosk WHERE id = $1",
+			[]interface{}{kiosk.ID}, sizing)
+		is.Error(err)
+		is.Contains(err.Error(), "cannot scan 300 into int8: value out of range")
+
+		kiosk.Tags = nil
+		kiosk.Sizes = []int16{}
+
+		err = makroud.Save(ctx, driver, kiosk)
+		is.NoError(err)
+
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(kiosk.ID))
+		is.NoError(err)
+		is.Nil(result.Tags)
+		is.NotNil(result.Sizes)
+		is.Empty(result.Sizes)
+	})
+}
+
+func TestSave_ValuerPrimaryKey(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		account := &Account{Name: "Bogo"}
+		err := makroud.Save(ctx, driver, account)
+		is.NoError(err)
+		is.NotZero(account.ID)
+
+		account.Name = "Chief Bogo"
+		err = makroud.Save(ctx, driver, account)
+		is.NoError(err)
+
+		count, err := makroud.Count(ctx, driver, loukoum.Select(loukoum.Count("id")).From("ztp_account"))
+		is.NoError(err)
+		is.Equal(int64(1), count)
+
+		result := &Account{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("name").Equal("Chief Bogo"))
+		is.NoError(err)
+		is.Equal(account.ID, result.ID)
+
+		err = makroud.Delete(ctx, driver, result)
+		is.NoError(err)
+
+		count, err = makroud.Count(ctx, driver, loukoum.Select(loukoum.Count("id")).From("ztp_account"))
+		is.NoError(err)
+		is.Equal(int64(0), count)
+	})
+}
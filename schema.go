@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/ulule/loukoum/v3/builder"
 
 	"github.com/ulule/makroud/reflectx"
 )
@@ -26,6 +27,7 @@ type Schema struct {
 	createdKey   *Field
 	updatedKey   *Field
 	deletedKey   *Field
+	scope        Scope
 }
 
 // Model returns the schema model.
@@ -121,6 +123,20 @@ func (schema Schema) DeletedKeyName() string {
 	panic(fmt.Sprint("makroud: ", ErrSchemaDeletedKey))
 }
 
+// HasDefaultScope returns if a default scope is defined for current schema.
+func (schema Schema) HasDefaultScope() bool {
+	return schema.scope != nil
+}
+
+// DefaultScope applies the schema default scope on given query.
+// If no default scope is defined, the query is returned as is.
+func (schema Schema) DefaultScope(query builder.Select) builder.Select {
+	if schema.scope == nil {
+		return query
+	}
+	return schema.scope(query)
+}
+
 // Columns returns schema columns without table prefix.
 func (schema Schema) Columns() Columns {
 	return schema.columns(false)
@@ -166,8 +182,12 @@ func (schema Schema) HasColumn(column string) bool {
 	return ok
 }
 
+// getValues returns the scan destinations of given columns in model.
+// If lenient is true, a column without destination is scanned in an empty interface and returned as discarded.
 // nolint: gocyclo
-func (schema Schema) getValues(value reflect.Value, columns []string, model Model) ([]interface{}, error) {
+func (schema Schema) getValues(driver Driver, value reflect.Value, columns []string, model Model,
+	lenient bool) ([]interface{}, []string, error) {
+
 	values := make([]interface{}, len(columns))
 	rest := make([]string, 0)
 	associationsColumns := map[string]map[string]int{}
@@ -180,14 +200,22 @@ func (schema Schema) getValues(value reflect.Value, columns []string, model Mode
 
 		field, ok := schema.fields[column]
 		if ok {
-			values[i] = reflectx.GetReflectFieldByIndexes(value, field.FieldIndex())
+			dest, err := getFieldDestination(driver, value, field)
+			if err != nil {
+				return nil, nil, err
+			}
+			values[i] = dest
 			continue
 		}
 
 		column = strings.TrimPrefix(column, fmt.Sprint(schema.TableName(), "."))
 		field, ok = schema.fields[column]
 		if ok {
-			values[i] = reflectx.GetReflectFieldByIndexes(value, field.FieldIndex())
+			dest, err := getFieldDestination(driver, value, field)
+			if err != nil {
+				return nil, nil, err
+			}
+			values[i] = dest
 			continue
 		}
 
@@ -212,14 +240,20 @@ func (schema Schema) getValues(value reflect.Value, columns []string, model Mode
 			continue
 		}
 
+		if lenient {
+			values[i] = new(interface{})
+		}
+
 		rest = append(rest, column)
 	}
 
-	if len(rest) > 0 {
-		return nil, errors.Wrapf(ErrSchemaColumnRequired,
+	if len(rest) > 0 && !lenient {
+		return nil, nil, errors.Wrapf(ErrSchemaColumnRequired,
 			"missing destination name %s in %T", strings.Join(rest, ", "), model)
 	}
 
+	discarded := rest
+
 	for key, columns := range associationsColumns {
 		// retrieve reflect field based on index
 		model := reflectx.GetReflectFieldByIndexes(value, schema.associations[key].Field.FieldIndex())
@@ -233,9 +267,10 @@ func (schema Schema) getValues(value reflect.Value, columns []string, model Mode
 			rest = append(rest, key)
 		}
 
-		associationValues, err := remote.Schema().getValues(associationValue, rest, remote.Model())
+		associationValues, others, err := remote.Schema().getValues(driver, associationValue, rest, remote.Model(),
+			lenient)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		for i := range associationValues {
@@ -244,49 +279,86 @@ func (schema Schema) getValues(value reflect.Value, columns []string, model Mode
 
 			values[index] = associationValues[i]
 		}
+
+		for i := range others {
+			discarded = append(discarded, fmt.Sprint(remote.TableName(), ".", others[i]))
+		}
 	}
 
-	return values, nil
+	return values, discarded, nil
+}
+
+// getFieldDestination returns the scan destination of given field.
+func getFieldDestination(driver Driver, value reflect.Value, field Field) (interface{}, error) {
+	dest := reflectx.GetReflectFieldByIndexes(value, field.FieldIndex())
+	if field.HasCodec() {
+		codec, err := getFieldCodec(driver, field)
+		if err != nil {
+			return nil, err
+		}
+		return codecScanner{field: field, codec: codec, dest: dest}, nil
+	}
+	if field.IsJSON() {
+		return jsonScanner{dest: dest}, nil
+	}
+	if field.IsArray() {
+		return arrayScanner{dest: dest}, nil
+	}
+	return dest, nil
 }
 
 // ScanRow executes a scan from given row into model.
+// A field using a codec cannot be scanned without a driver: please use Exec helpers instead.
 func (schema Schema) ScanRow(row Row, model Model) error {
+	_, err := schema.scanRow(nil, row, model, false)
+	return err
+}
+
+// ScanRows executes a scan from current row into model.
+// A field using a codec cannot be scanned without a driver: please use Exec helpers instead.
+func (schema Schema) ScanRows(rows Rows, model Model) error {
+	_, err := schema.scanRows(nil, rows, model, false)
+	return err
+}
+
+// scanRow executes a scan from given row into model, and returns the discarded columns if lenient is true.
+func (schema Schema) scanRow(driver Driver, row Row, model Model, lenient bool) ([]string, error) {
 	columns, err := row.Columns()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	value := reflectx.GetIndirectValue(model)
 	if !reflectx.IsStruct(value) {
-		return errors.Wrapf(ErrStructRequired, "cannot use mapper on %T", model)
+		return nil, errors.Wrapf(ErrStructRequired, "cannot use mapper on %T", model)
 	}
 
-	values, err := schema.getValues(value, columns, model)
+	values, discarded, err := schema.getValues(driver, value, columns, model, lenient)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return row.Scan(values...)
+	return discarded, row.Scan(values...)
 }
 
-// ScanRows executes a scan from current row into model.
-func (schema Schema) ScanRows(rows Rows, model Model) error {
+// scanRows executes a scan from current row into model, and returns the discarded columns if lenient is true.
+func (schema Schema) scanRows(driver Driver, rows Rows, model Model, lenient bool) ([]string, error) {
 	columns, err := rows.Columns()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	value := reflectx.GetIndirectValue(model)
 	if !reflectx.IsStruct(value) {
-		return errors.Wrapf(ErrStructRequired, "cannot use mapper on %T", model)
+		return nil, errors.Wrapf(ErrStructRequired, "cannot use mapper on %T", model)
 	}
 
-	values, err := schema.getValues(value, columns, model)
+	values, discarded, err := schema.getValues(driver, value, columns, model, lenient)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return rows.Scan(values...)
+	return discarded, rows.Scan(values...)
 }
 
 // ----------------------------------------------------------------------------
@@ -299,6 +371,23 @@ func GetSchema(driver Driver, model Model) (*Schema, error) {
 	return getSchema(driver, model, true)
 }
 
+// RegisterModels builds and validates the schema of given models, so an invalid model returns an error at startup
+// instead of during its first query. If driver has a cache, these schemas are cached.
+func RegisterModels(driver Driver, models ...Model) error {
+	if driver == nil {
+		return errors.WithStack(ErrInvalidDriver)
+	}
+
+	for i := range models {
+		_, err := GetSchema(driver, models[i])
+		if err != nil {
+			return errors.Wrapf(err, "makroud: cannot register %T", models[i])
+		}
+	}
+
+	return nil
+}
+
 // getSchema returns the schema from given model.
 // If the schema does not exists, it returns an error.
 // If throughout is true, it will execute a full scan of given model:
@@ -370,6 +459,21 @@ func analyzeModelOpts(model Model) ModelOpts {
 	return opts
 }
 
+// analyzeModelScope analyzes given model to extract it's default scope, if any.
+func analyzeModelScope(model Model) Scope {
+	// Use a pointer on a zero value so both value and pointer receivers are detected.
+	instance := reflect.New(reflectx.GetIndirectType(model)).Interface()
+
+	scoper, ok := instance.(interface {
+		DefaultScope(query builder.Select) builder.Select
+	})
+	if ok {
+		return scoper.DefaultScope
+	}
+
+	return nil
+}
+
 // newSchema returns a schema from given model, extracted by reflection.
 // The returned schema is a mapping of a model to table and columns.
 // For example: Model.FieldName -> table_name.column_name
@@ -377,7 +481,7 @@ func analyzeModelOpts(model Model) ModelOpts {
 // If throughout is true, it will execute a full and complete scan of given model:
 // this is a trick to allow circular import of model.
 func newSchema(driver Driver, model Model, throughout bool) (*Schema, error) {
-	fields, err := reflectx.GetFields(model)
+	fields, err := getStructFields(model)
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot use reflections to obtain %T fields", model)
 	}
@@ -391,6 +495,7 @@ func newSchema(driver Driver, model Model, throughout bool) (*Schema, error) {
 		fields:       map[string]Field{},
 		references:   map[string]ForeignKey{},
 		associations: map[string]Reference{},
+		scope:        analyzeModelScope(model),
 	}
 
 	relationships := map[string]*Field{}
@@ -416,10 +521,14 @@ func newSchema(driver Driver, model Model, throughout bool) (*Schema, error) {
 }
 
 func getSchemaFields(driver Driver, schema *Schema, model Model, modelOpts ModelOpts,
-	fields []string, relationships map[string]*Field) error {
+	fields []structField, relationships map[string]*Field) error {
+
+	columns := map[string]string{}
 
-	for _, name := range fields {
-		field, err := NewField(driver, schema, model, name, modelOpts)
+	for i := range fields {
+		name := fields[i].name
+
+		field, err := newField(driver, model, fields[i], modelOpts)
 		if err != nil {
 			return err
 		}
@@ -428,6 +537,15 @@ func getSchemaFields(driver Driver, schema *Schema, model Model, modelOpts Model
 			continue
 		}
 
+		if !field.IsAssociation() {
+			previous, ok := columns[field.ColumnName()]
+			if ok {
+				return errors.Errorf("%T must have only one field for column '%s': '%s' and '%s' are mapped on it",
+					model, field.ColumnName(), previous, name)
+			}
+			columns[field.ColumnName()] = name
+		}
+
 		err = inferSchemaTimeKey(model, modelOpts, schema, field)
 		if err != nil {
 			return err
@@ -3,10 +3,12 @@ package makroud_test
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"github.com/ulule/loukoum/v3"
 
 	"github.com/ulule/makroud"
 )
@@ -351,6 +353,108 @@ func TestSchema_Human(t *testing.T) {
 	})
 }
 
+func TestSchema_Kiosk(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+		model := &Kiosk{}
+
+		schema, err := makroud.GetSchema(driver, model)
+		is.NoError(err)
+		is.NotNil(schema)
+
+		is.Equal("ztp_kiosk", schema.TableName())
+		is.Equal("id", schema.PrimaryKey().ColumnName())
+		is.True(schema.HasCreatedKey())
+		is.True(schema.HasUpdatedKey())
+		is.True(schema.HasDeletedKey())
+		is.Equal("ztp_kiosk.created_at", schema.CreatedKeyPath())
+
+		columns := schema.Columns()
+		is.Len(columns, 13)
+		is.Contains(columns, "billing_street")
+		is.Contains(columns, "billing_city")
+		is.Contains(columns, "shipping_street")
+		is.Contains(columns, "shipping_city")
+		is.Contains(columns, "created_at")
+		is.Contains(columns, "updated_at")
+		is.Contains(columns, "deleted_at")
+		is.False(schema.HasColumn("billing"))
+		is.False(schema.HasColumn("timestamps"))
+
+		kiosk := &Kiosk{
+			Name:     "Sahara Square",
+			Billing:  Location{Street: "1 Dune Road", City: "Zootopia"},
+			Shipping: Location{Street: "2 Oasis Road", City: "Zootopia"},
+		}
+
+		err = makroud.Save(ctx, driver, kiosk)
+		is.NoError(err)
+		is.NotZero(kiosk.ID)
+		is.NotZero(kiosk.CreatedAt)
+
+		result := &Kiosk{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(kiosk.ID))
+		is.NoError(err)
+		is.Equal(kiosk.Billing, result.Billing)
+		is.Equal(kiosk.Shipping, result.Shipping)
+		is.False(result.CreatedAt.IsZero())
+
+		type Address struct {
+			Location `mk:"embed,prefix:billing_"`
+			Name     string
+		}
+
+		schemaless, err := makroud.GetSchemaless(driver, reflect.TypeOf(Address{}))
+		is.NoError(err)
+
+		key, ok := schemaless.Key("billing_city")
+		is.True(ok)
+		is.Equal([]int{0, 1}, key.FieldIndex())
+		is.Equal("City", key.FieldName())
+
+		address := &Address{}
+		err = makroud.RawExec(ctx, driver, "SELECT name, billing_street, billing_city FROM ztp_kiosk", address)
+		is.NoError(err)
+		is.Equal(kiosk.Name, address.Name)
+		is.Equal(kiosk.Billing, address.Location)
+
+		type Invalid struct {
+			ID      int64  `makroud:"column:id,pk"`
+			Billing string `makroud:"embed"`
+		}
+
+		_, err = makroud.GetSchemaless(driver, reflect.TypeOf(Invalid{}))
+		is.Error(err)
+	})
+}
+
+func TestSchema_DuplicateColumns(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		is := require.New(t)
+
+		_, err := makroud.GetSchema(driver, &Stall{})
+		is.Error(err)
+		is.Contains(err.Error(), "column 'created_at'")
+
+		_, err = makroud.GetSchema(driver, &Booth{})
+		is.Error(err)
+		is.Contains(err.Error(), "column 'street': 'Billing.Street' and 'Shipping.Street'")
+
+		type Street struct {
+			City string `makroud:"city"`
+		}
+		type Address struct {
+			Street
+			Town string `makroud:"city"`
+		}
+
+		_, err = makroud.GetSchemaless(driver, reflect.TypeOf(Address{}))
+		is.Error(err)
+		is.Contains(err.Error(), "column 'city': 'City' and 'Town'")
+	})
+}
+
 func TestColumns_Owl(t *testing.T) {
 	Setup(t)(func(driver makroud.Driver) {
 		is := require.New(t)
@@ -436,3 +540,59 @@ func TestColumns_ExoChunk(t *testing.T) {
 
 	})
 }
+
+func TestSchema_SameTable(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		cat := &Cat{Name: "Garfield"}
+		err := makroud.Save(ctx, driver, cat)
+		is.NoError(err)
+
+		schema1, err := makroud.GetSchema(driver, &Cat{})
+		is.NoError(err)
+		is.Equal("Cat", schema1.ModelName())
+
+		schema2, err := makroud.GetSchema(driver, &CatName{})
+		is.NoError(err)
+		is.Equal("CatName", schema2.ModelName())
+		is.Equal(schema1.TableName(), schema2.TableName())
+		is.Len(schema2.Columns(), 2)
+
+		schema3, err := makroud.GetSchema(driver, CatName{})
+		is.NoError(err)
+		is.True(schema2 == schema3)
+
+		names := []CatName{}
+		err = makroud.Select(ctx, driver, &names)
+		is.NoError(err)
+		is.Len(names, 1)
+		is.Equal(cat.ID, names[0].ID)
+		is.Equal("Garfield", names[0].Name)
+
+		result := &Cat{}
+		err = makroud.Select(ctx, driver, result, loukoum.Condition("id").Equal(cat.ID))
+		is.NoError(err)
+		is.Equal(cat.ID, result.ID)
+		is.False(result.CreatedAt.IsZero())
+	})
+}
+
+func TestSchema_RegisterModels(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		is := require.New(t)
+
+		err := makroud.RegisterModels(driver, &Cat{}, &CatName{}, &Meow{}, &Owl{})
+		is.NoError(err)
+
+		err = makroud.RegisterModels(driver, &Cat{}, &Informant{})
+		is.Error(err)
+		is.Contains(err.Error(), "makroud: cannot register *makroud_test.Informant")
+		is.Contains(err.Error(), "codec 'rot13' of field 'Name' is not registered")
+
+		err = makroud.RegisterModels(nil, &Cat{})
+		is.Error(err)
+		is.Equal(makroud.ErrInvalidDriver, errors.Cause(err))
+	})
+}
@@ -50,64 +50,91 @@ func (schema Schemaless) Key(column string) (SchemalessKey, bool) {
 
 // ScanRow executes a scan from given row into schemaless instance.
 func (schema Schemaless) ScanRow(row Row, val interface{}) error {
+	_, err := schema.scanRow(row, val, false)
+	return err
+}
+
+// ScanRows executes a scan from current row into schemaless instance.
+func (schema Schemaless) ScanRows(rows Rows, val interface{}) error {
+	_, err := schema.scanRows(rows, val, false)
+	return err
+}
+
+// scanRow executes a scan from given row into schemaless instance, and returns the discarded columns
+// if lenient is true.
+func (schema Schemaless) scanRow(row Row, val interface{}, lenient bool) ([]string, error) {
 	columns, err := row.Columns()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	value := reflectx.GetIndirectValue(val)
 	if !reflectx.IsStruct(value) {
-		return errors.Wrapf(ErrStructRequired, "cannot use mapper on %T", val)
+		return nil, errors.Wrapf(ErrStructRequired, "cannot use mapper on %T", val)
 	}
 
-	values, err := schema.getValues(value, columns, val)
+	values, discarded, err := schema.getValues(value, columns, val, lenient)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return row.Scan(values...)
+	return discarded, row.Scan(values...)
 }
 
-// ScanRows executes a scan from current row into schemaless instance.
-func (schema Schemaless) ScanRows(rows Rows, val interface{}) error {
+// scanRows executes a scan from current row into schemaless instance, and returns the discarded columns
+// if lenient is true.
+func (schema Schemaless) scanRows(rows Rows, val interface{}, lenient bool) ([]string, error) {
 	columns, err := rows.Columns()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	value := reflectx.GetIndirectValue(val)
 	if !reflectx.IsStruct(value) {
-		return errors.Wrapf(ErrStructRequired, "cannot use mapper on %T", val)
+		return nil, errors.Wrapf(ErrStructRequired, "cannot use mapper on %T", val)
 	}
 
-	values, err := schema.getValues(value, columns, val)
+	values, discarded, err := schema.getValues(value, columns, val, lenient)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return rows.Scan(values...)
+	return discarded, rows.Scan(values...)
 }
 
-func (schema Schemaless) getValues(value reflect.Value, columns []string, val interface{}) ([]interface{}, error) {
+// getValues returns the scan destinations of given columns in val.
+// If lenient is true, a column without destination is scanned in an empty interface and returned as discarded.
+func (schema Schemaless) getValues(value reflect.Value, columns []string, val interface{},
+	lenient bool) ([]interface{}, []string, error) {
+
 	values := make([]interface{}, len(columns))
 	missing := make([]string, 0)
 
 	for i, column := range columns {
 		key, ok := schema.keys[column]
 		if !ok {
+			if lenient {
+				values[i] = new(interface{})
+			}
 			missing = append(missing, column)
 			continue
 		}
 
 		values[i] = reflectx.GetReflectFieldByIndexes(value, key.FieldIndex())
+		if key.IsJSON() {
+			values[i] = jsonScanner{dest: values[i]}
+		}
+		if key.IsArray() {
+			values[i] = arrayScanner{dest: values[i]}
+		}
 	}
 
-	if len(missing) > 0 {
-		return nil, errors.Wrapf(ErrSchemaColumnRequired,
+	if len(missing) > 0 && !lenient {
+		return nil, nil, errors.Wrapf(ErrSchemaColumnRequired,
 			"missing destination name %s in %T", strings.Join(missing, ", "), val)
 	}
 
-	return values, nil
+	return values, missing, nil
 }
 
 // SchemalessKey is a light version of schema field.
@@ -116,6 +143,8 @@ type SchemalessKey struct {
 	columnName string
 	fieldName  string
 	fieldIndex []int
+	isJSON     bool
+	isArray    bool
 }
 
 // ColumnName returns the column name for this schemaless key.
@@ -133,6 +162,16 @@ func (key SchemalessKey) FieldIndex() []int {
 	return key.fieldIndex
 }
 
+// IsJSON returns if this schemaless key is stored in a JSON column.
+func (key SchemalessKey) IsJSON() bool {
+	return key.isJSON
+}
+
+// IsArray returns if this schemaless key is stored in an array column.
+func (key SchemalessKey) IsArray() bool {
+	return key.isArray
+}
+
 // ----------------------------------------------------------------------------
 // Initializers
 // ----------------------------------------------------------------------------
@@ -166,7 +205,7 @@ func GetSchemaless(driver Driver, value reflect.Type) (*Schemaless, error) {
 // If you need a mapping with a database table, please use a Schema instead of a Schemaless instance.
 // You'll have better features such as primary key, foreign key, associations and so on...
 func newSchemaless(driver Driver, value reflect.Type) (*Schemaless, error) {
-	fields, err := reflectx.GetFields(value)
+	fields, err := getStructFields(value)
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot use reflections to obtain %s fields", value.String())
 	}
@@ -176,13 +215,9 @@ func newSchemaless(driver Driver, value reflect.Type) (*Schemaless, error) {
 		keys:  map[string]SchemalessKey{},
 	}
 
-	for _, name := range fields {
-		field, ok := reflectx.GetFieldByName(value, name)
-		if !ok {
-			return nil, errors.Errorf("field '%s' not found in model", name)
-		}
-
-		tags := GetTags(field, NewOnlyColumnTagsAnalyzerOption())
+	for _, field := range fields {
+		tags := GetTags(field.StructField, NewOnlyColumnTagsAnalyzerOption())
+		modifiers := GetTags(field.StructField)
 
 		isExcluded := tags.HasKey(TagName, TagKeyIgnored) || field.PkgPath != ""
 		if isExcluded {
@@ -191,13 +226,29 @@ func newSchemaless(driver Driver, value reflect.Type) (*Schemaless, error) {
 
 		columnName := tags.GetByKey(TagName, TagKeyColumn)
 		if columnName == "" {
-			columnName = snaker.CamelToSnake(name)
+			columnName = snaker.CamelToSnake(field.Name)
+		}
+		columnName = field.prefix + columnName
+
+		previous, ok := schema.keys[columnName]
+		if ok {
+			return nil, errors.Errorf("%s must have only one field for column '%s': '%s' and '%s' are mapped on it",
+				value.String(), columnName, previous.FieldName(), field.name)
+		}
+
+		rtype := reflectx.GetIndirectType(field.Type)
+		isJSON := modifiers.HasKey(TagName, TagKeyJSON)
+		isArray := !isJSON && (modifiers.HasKey(TagName, TagKeyArray) || isArrayType(rtype))
+		if isArray && rtype.Kind() != reflect.Slice && rtype.Kind() != reflect.Array {
+			return nil, errors.Errorf("field '%s' of %s must be a slice to be an array", field.name, value.String())
 		}
 
 		key := SchemalessKey{
 			columnName: columnName,
-			fieldName:  field.Name,
+			fieldName:  field.name,
 			fieldIndex: field.Index,
+			isJSON:     isJSON,
+			isArray:    isArray,
 		}
 
 		schema.keys[key.ColumnName()] = key
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
@@ -80,6 +81,41 @@ func TestSchemaless_Hash(t *testing.T) {
 	})
 }
 
+func TestSchemaless_Array(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		is := require.New(t)
+
+		type Label struct {
+			Name  string         `mk:"name"`
+			Tags  []string       `mk:"tags"`
+			Codes pq.StringArray `mk:"codes,array"`
+		}
+
+		schema, err := makroud.GetSchemaless(driver, reflectx.GetIndirectType(&Label{}))
+		is.NoError(err)
+
+		key, ok := schema.Key("name")
+		is.True(ok)
+		is.False(key.IsArray())
+
+		key, ok = schema.Key("tags")
+		is.True(ok)
+		is.True(key.IsArray())
+
+		key, ok = schema.Key("codes")
+		is.True(ok)
+		is.True(key.IsArray())
+
+		type Invalid struct {
+			Name string `mk:"name,array"`
+		}
+
+		_, err = makroud.GetSchemaless(driver, reflectx.GetIndirectType(&Invalid{}))
+		is.Error(err)
+		is.Contains(err.Error(), "must be a slice to be an array")
+	})
+}
+
 func TestSchemaless_PartialHuman(t *testing.T) {
 	Setup(t, makroud.Cache(false))(func(driver makroud.Driver) {
 		ctx := context.Background()
@@ -9,13 +9,18 @@ import (
 	"github.com/ulule/loukoum/v3/builder"
 	"github.com/ulule/loukoum/v3/stmt"
 
+	"github.com/ulule/makroud/hooks"
 	"github.com/ulule/makroud/reflectx"
 )
 
 // Select retrieves the given instance using given arguments as criteria.
-// This method accepts loukoum's stmt.Order, stmt.Offet, stmt.Limit and stmt.Expression as arguments.
-// For unsupported statement, they will be ignored.
+// This method accepts loukoum's stmt.Order, stmt.Offet, stmt.Limit, stmt.Expression and Scope as arguments.
+// For unsupported statement, an error is returned.
+//
+// The model default scope and its deleted key filter are applied, unless Unscoped is given as argument.
+// If the model has the driver tenant column, the tenant from context is always applied.
 func Select(ctx context.Context, driver Driver, dest interface{}, args ...interface{}) error {
+	ctx = hooks.WithOperation(ctx, "Select")
 	if !reflectx.IsPointer(dest) {
 		return errors.Wrapf(ErrPointerRequired, "makroud: cannot execute query on %T", dest)
 	}
@@ -38,15 +43,23 @@ func selectRow(ctx context.Context, driver Driver, dest interface{}, args []inte
 
 	columns := schema.ColumnPaths()
 
-	query, parsed := parseSelectArgs(loukoum.Select(columns.List()).From(model.TableName()), args)
+	query, parsed, err := parseSelectArgs(loukoum.Select(columns.List()).From(model.TableName()), args)
+	if err != nil {
+		return err
+	}
 	if !parsed.hasLimit {
 		query = query.Limit(1)
 	}
 	if !parsed.hasOrder {
 		query = query.OrderBy(loukoum.Order(schema.PrimaryKeyName()))
 	}
-	if schema.HasDeletedKey() {
-		query = query.Where(loukoum.Condition(schema.DeletedKeyName()).IsNull(true))
+	if !parsed.unscoped {
+		query = applySchemaScopes(schema, query)
+	}
+
+	query, err = applyTenantScope(ctx, driver, schema, query)
+	if err != nil {
+		return errors.Wrapf(err, "makroud: cannot execute query on %T", dest)
 	}
 
 	return Exec(ctx, driver, query, dest)
@@ -65,25 +78,66 @@ func selectRows(ctx context.Context, driver Driver, dest interface{}, args []int
 
 	columns := schema.ColumnPaths()
 
-	query, parsed := parseSelectArgs(loukoum.Select(columns.List()).From(model.TableName()), args)
+	query, parsed, err := parseSelectArgs(loukoum.Select(columns.List()).From(model.TableName()), args)
+	if err != nil {
+		return err
+	}
 	if !parsed.hasOrder {
 		query = query.OrderBy(loukoum.Order(schema.PrimaryKeyName()))
 	}
-	if schema.HasDeletedKey() {
-		query = query.Where(loukoum.Condition(schema.DeletedKeyName()).IsNull(true))
+	if !parsed.unscoped {
+		query = applySchemaScopes(schema, query)
+	}
+
+	query, err = applyTenantScope(ctx, driver, schema, query)
+	if err != nil {
+		return errors.Wrapf(err, "makroud: cannot execute query on %T", dest)
 	}
 
 	return Exec(ctx, driver, query, dest)
 }
 
+// Scope is a reusable query fragment that can be given as argument to Select, Count and WithPreloadCallback.
+// Scopes are applied in the given order.
+type Scope func(query builder.Select) builder.Select
+
+// Scopes returns a Scope that applies the given scopes in order.
+func Scopes(scopes ...Scope) Scope {
+	return func(query builder.Select) builder.Select {
+		for i := range scopes {
+			query = scopes[i](query)
+		}
+		return query
+	}
+}
+
+// UnscopedArg is an argument for Select and Count that disables the automatic scopes of a model.
+type UnscopedArg struct{}
+
+// Unscoped returns an argument for Select and Count that disables the automatic scopes of a model,
+// such as its deleted key filter and its default scope.
+func Unscoped() UnscopedArg {
+	return UnscopedArg{}
+}
+
+// applySchemaScopes applies the automatic scopes of given schema on query:
+// its deleted key filter and its default scope.
+func applySchemaScopes(schema *Schema, query builder.Select) builder.Select {
+	if schema.HasDeletedKey() {
+		query = query.Where(loukoum.Condition(schema.DeletedKeyName()).IsNull(true))
+	}
+	return schema.DefaultScope(query)
+}
+
 type parsedSelectArgs struct {
 	hasLimit      bool
 	hasOffset     bool
 	hasOrder      bool
 	hasExpression bool
+	unscoped      bool
 }
 
-func parseSelectArgs(query builder.Select, args []interface{}) (builder.Select, parsedSelectArgs) {
+func parseSelectArgs(query builder.Select, args []interface{}) (builder.Select, parsedSelectArgs, error) {
 	result := parsedSelectArgs{}
 	for i := range args {
 		switch v := args[i].(type) {
@@ -99,7 +153,29 @@ func parseSelectArgs(query builder.Select, args []interface{}) (builder.Select,
 		case stmt.Expression:
 			result.hasExpression = true
 			query = query.Where(v)
+		case Scope:
+			query = v(query)
+		case func(query builder.Select) builder.Select:
+			query = v(query)
+		case UnscopedArg:
+			result.unscoped = true
+		default:
+			return query, result, errors.Errorf("makroud: unsupported argument %T", v)
 		}
 	}
-	return query, result
+
+	return query, inspectSelectArgs(query, result), nil
+}
+
+// inspectSelectArgs updates given result with the clauses defined on query.
+// Scopes may define their own clauses, so we have to inspect the resulting statement.
+func inspectSelectArgs(query builder.Select, result parsedSelectArgs) parsedSelectArgs {
+	statement, ok := query.Statement().(stmt.Select)
+	if ok {
+		result.hasLimit = result.hasLimit || !statement.Limit.IsEmpty()
+		result.hasOffset = result.hasOffset || !statement.Offset.IsEmpty()
+		result.hasOrder = result.hasOrder || !statement.OrderBy.IsEmpty()
+		result.hasExpression = result.hasExpression || !statement.Where.IsEmpty()
+	}
+	return result
 }
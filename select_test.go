@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/ulule/loukoum/v3"
+	"github.com/ulule/loukoum/v3/builder"
 
 	"github.com/ulule/makroud"
 )
@@ -178,3 +179,195 @@ func TestSelect_Rows(t *testing.T) {
 		}
 	})
 }
+
+func TestSelect_DefaultScope(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		owls := []Owl{
+			{Name: "Pilou", FeatherColor: "brown", FavoriteFood: "Shrimps"},
+			{Name: "Minus", FeatherColor: "grey", FavoriteFood: "Mice"},
+			{Name: "Cortex", FeatherColor: "white", FavoriteFood: "Shrimps"},
+		}
+
+		for i := range owls {
+			err := makroud.Save(ctx, driver, &owls[i])
+			is.NoError(err)
+		}
+
+		{
+			result := &[]ShrimpOwl{}
+			err := makroud.Select(ctx, driver, result)
+			is.NoError(err)
+			is.Len(*result, 2)
+			is.Equal(owls[0].ID, (*result)[0].ID)
+			is.Equal(owls[2].ID, (*result)[1].ID)
+		}
+		{
+			result := &ShrimpOwl{}
+			err := makroud.Select(ctx, driver, result, loukoum.Condition("name").Equal("Minus"))
+			is.Error(err)
+			is.True(makroud.IsErrNoRows(err))
+		}
+		{
+			result := &[]ShrimpOwl{}
+			err := makroud.Select(ctx, driver, result, makroud.Unscoped())
+			is.NoError(err)
+			is.Len(*result, 3)
+		}
+		{
+			query := loukoum.Select(loukoum.Count("*")).From("ztp_owl")
+
+			count, err := makroud.Count(ctx, driver, query, &ShrimpOwl{})
+			is.NoError(err)
+			is.Equal(int64(2), count)
+
+			count, err = makroud.Count(ctx, driver, query, &ShrimpOwl{}, makroud.Unscoped())
+			is.NoError(err)
+			is.Equal(int64(3), count)
+
+			count, err = makroud.Count(ctx, driver, query)
+			is.NoError(err)
+			is.Equal(int64(3), count)
+		}
+	})
+}
+
+func TestSelect_DefaultScopePreload(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		group := &Group{Name: "Parliament"}
+		err := makroud.Save(ctx, driver, group)
+		is.NoError(err)
+
+		owls := []Owl{
+			{Name: "Pilou", FeatherColor: "brown", FavoriteFood: "Shrimps"},
+			{Name: "Minus", FeatherColor: "grey", FavoriteFood: "Mice"},
+			{Name: "Cortex", FeatherColor: "white", FavoriteFood: "Shrimps"},
+		}
+
+		for i := range owls {
+			owls[i].GroupID.Valid = true
+			owls[i].GroupID.Int64 = group.ID
+			err = makroud.Save(ctx, driver, &owls[i])
+			is.NoError(err)
+		}
+
+		{
+			flock := &Flock{}
+			err = makroud.Select(ctx, driver, flock, loukoum.Condition("id").Equal(group.ID))
+			is.NoError(err)
+
+			err = makroud.Preload(ctx, driver, flock, makroud.WithPreloadField("Owls"))
+			is.NoError(err)
+			is.Len(flock.Owls, 2)
+			for _, owl := range flock.Owls {
+				is.Equal("Shrimps", owl.FavoriteFood)
+			}
+		}
+		{
+			flock := &Flock{}
+			err = makroud.Select(ctx, driver, flock, loukoum.Condition("id").Equal(group.ID))
+			is.NoError(err)
+
+			err = makroud.Preload(ctx, driver, flock,
+				makroud.WithUnscopedPreload(makroud.WithPreloadField("Owls")))
+			is.NoError(err)
+			is.Len(flock.Owls, 3)
+		}
+	})
+}
+
+func TestSelect_Scope(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		humans := []Human{
+			{Name: "Mahaut"},
+			{Name: "Blanche"},
+			{Name: "Mathilde"},
+			{Name: "Aliénor"},
+		}
+
+		for i := range humans {
+			err := makroud.Save(ctx, driver, &humans[i])
+			is.NoError(err)
+		}
+
+		sort.Slice(humans, func(i, j int) bool {
+			return humans[i].ID < humans[j].ID
+		})
+
+		prefixed := func(prefix string) makroud.Scope {
+			return func(query builder.Select) builder.Select {
+				return query.Where(loukoum.Condition("name").Like(fmt.Sprint(prefix, "%")))
+			}
+		}
+
+		latest := makroud.Scope(func(query builder.Select) builder.Select {
+			return query.OrderBy(loukoum.Order("id", loukoum.Desc)).Limit(1)
+		})
+
+		{
+			result := &[]Human{}
+			err := makroud.Select(ctx, driver, result, prefixed("Ma"))
+			is.NoError(err)
+			is.Len(*result, 2)
+			for i := range *result {
+				is.Contains([]string{"Mahaut", "Mathilde"}, (*result)[i].Name)
+			}
+		}
+		{
+			result := &[]Human{}
+			err := makroud.Select(ctx, driver, result, makroud.Scopes(prefixed("Ma"), latest))
+			is.NoError(err)
+			is.Len(*result, 1)
+		}
+		{
+			result := &Human{}
+			err := makroud.Select(ctx, driver, result, latest)
+			is.NoError(err)
+			is.Equal(humans[3].ID, result.ID)
+		}
+		{
+			query := loukoum.Select(loukoum.Count("*")).From("ztp_human")
+
+			count, err := makroud.Count(ctx, driver, query, prefixed("Ma"))
+			is.NoError(err)
+			is.Equal(int64(2), count)
+		}
+		{
+			cat := &Cat{Name: "Mahaut"}
+			err := makroud.Save(ctx, driver, cat)
+			is.NoError(err)
+
+			for _, body := range []string{"meow", "purr", "meooow"} {
+				err = makroud.Save(ctx, driver, &Meow{Body: body, CatID: cat.ID})
+				is.NoError(err)
+			}
+
+			meowing := makroud.Scope(func(query builder.Select) builder.Select {
+				return query.Where(loukoum.Condition("body").Like("me%"))
+			})
+
+			err = makroud.Preload(ctx, driver, cat, makroud.WithPreloadCallback("Meows", meowing))
+			is.NoError(err)
+			is.Len(cat.Meows, 2)
+		}
+		{
+			result := &[]Human{}
+			err := makroud.Select(ctx, driver, result, "Mahaut")
+			is.Error(err)
+			is.Contains(err.Error(), "makroud: unsupported argument string")
+
+			query := loukoum.Select(loukoum.Count("*")).From("ztp_human")
+			_, err = makroud.Count(ctx, driver, query, loukoum.Condition("name").Equal("Mahaut"))
+			is.Error(err)
+			is.Contains(err.Error(), "makroud: unsupported argument")
+		}
+	})
+}
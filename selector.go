@@ -3,6 +3,7 @@ package makroud
 import (
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -25,6 +26,9 @@ type Selector struct {
 	cache          *DriverCache
 	configurations map[string]*ClientOptions
 	connections    map[string]Driver
+	unhealthy      map[string]error
+	stop           chan struct{}
+	wait           sync.WaitGroup
 }
 
 // NewSelector returns a new selector containing a pool of drivers with given configuration.
@@ -35,6 +39,7 @@ func NewSelector(configurations map[string]*ClientOptions) (*Selector, error) {
 		configurations: configurations,
 		cache:          NewDriverCache(),
 		connections:    connections,
+		unhealthy:      map[string]error{},
 	}
 
 	return selector, nil
@@ -48,6 +53,7 @@ func NewSelectorWithDriver(driver Driver) (*Selector, error) {
 		connections: map[string]Driver{
 			DefaultSelector: driver,
 		},
+		unhealthy: map[string]error{},
 	}
 
 	return selector, nil
@@ -59,6 +65,7 @@ func NewSelectorWithDrivers(drivers map[string]Driver) (*Selector, error) {
 		configurations: map[string]*ClientOptions{},
 		cache:          NewDriverCache(),
 		connections:    drivers,
+		unhealthy:      map[string]error{},
 	}
 
 	return selector, nil
@@ -106,7 +113,18 @@ func (selector *Selector) Using(alias string) (Driver, error) {
 }
 
 // RetryAliases is an helper calling Retry with a list of aliases.
+// Aliases marked as unhealthy by the health checker are skipped, unless every given aliases are unhealthy.
 func (selector *Selector) RetryAliases(handler func(Driver) error, aliases ...string) error {
+	healthy := []string{}
+	for _, alias := range aliases {
+		if selector.IsHealthy(alias) {
+			healthy = append(healthy, alias)
+		}
+	}
+	if len(healthy) > 0 {
+		aliases = healthy
+	}
+
 	drivers := []Driver{}
 
 	for _, alias := range aliases {
@@ -126,8 +144,161 @@ func (selector *Selector) RetryMaster(handler func(Driver) error) error {
 	return selector.RetryAliases(handler, SlaveSelector, MasterSelector)
 }
 
+// IsHealthy returns if given alias hasn't been marked as unhealthy by the health checker.
+func (selector *Selector) IsHealthy(alias string) bool {
+	alias = strings.ToLower(alias)
+
+	selector.mutex.RLock()
+	defer selector.mutex.RUnlock()
+
+	_, unhealthy := selector.unhealthy[alias]
+	return !unhealthy
+}
+
+// StartHealthCheck starts a background health checker, which pings every connection on given interval.
+// A connection failing its ping is marked as unhealthy until it succeeds again.
+// Every change is reported to the connection observer, if any.
+func (selector *Selector) StartHealthCheck(interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("makroud: health check interval must be greater than zero")
+	}
+
+	selector.mutex.Lock()
+	defer selector.mutex.Unlock()
+
+	if selector.stop != nil {
+		return nil
+	}
+
+	stop := make(chan struct{})
+	selector.stop = stop
+	selector.wait.Add(1)
+
+	go selector.healthCheck(interval, stop)
+
+	return nil
+}
+
+// StopHealthCheck stops the background health checker, if started.
+func (selector *Selector) StopHealthCheck() {
+	selector.mutex.Lock()
+	stop := selector.stop
+	selector.stop = nil
+	selector.mutex.Unlock()
+
+	if stop != nil {
+		stop <- struct{}{}
+		selector.wait.Wait()
+	}
+}
+
+// CheckHealth pings every connection once and updates their health status.
+func (selector *Selector) CheckHealth() {
+	for _, alias := range selector.aliases() {
+		connection, err := selector.Using(alias)
+		if err == nil {
+			err = connection.Ping()
+		}
+
+		selector.setHealth(alias, connection, err)
+	}
+}
+
+// healthCheck executes CheckHealth on given interval until stop is closed.
+func (selector *Selector) healthCheck(interval time.Duration, stop chan struct{}) {
+	defer selector.wait.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			selector.CheckHealth()
+		}
+	}
+}
+
+// aliases returns every known alias, either configured or connected.
+func (selector *Selector) aliases() []string {
+	selector.mutex.RLock()
+	defer selector.mutex.RUnlock()
+
+	aliases := []string{}
+	exists := map[string]bool{}
+
+	for name := range selector.configurations {
+		alias := strings.ToLower(name)
+		if !exists[alias] {
+			exists[alias] = true
+			aliases = append(aliases, alias)
+		}
+	}
+
+	for name := range selector.connections {
+		alias := strings.ToLower(name)
+		if !exists[alias] {
+			exists[alias] = true
+			aliases = append(aliases, alias)
+		}
+	}
+
+	return aliases
+}
+
+// setHealth updates the health status of given alias, and reports any change to the observer.
+func (selector *Selector) setHealth(alias string, connection Driver, err error) {
+	selector.mutex.Lock()
+
+	_, unhealthy := selector.unhealthy[alias]
+	if (err != nil) == unhealthy {
+		selector.mutex.Unlock()
+		return
+	}
+
+	flags := map[string]string{
+		"alias": alias,
+	}
+
+	if err != nil {
+		err = errors.Wrapf(err, "makroud: connection %s is unhealthy", alias)
+		selector.unhealthy[alias] = err
+		flags["action"] = "eject"
+	} else {
+		delete(selector.unhealthy, alias)
+		flags["action"] = "reinstate"
+	}
+
+	observer, ok := selector.observer(alias, connection).(HealthObserver)
+	selector.mutex.Unlock()
+
+	if ok {
+		observer.OnHealthCheck(err, flags)
+	}
+}
+
+// observer returns the observer of given alias, if any.
+func (selector *Selector) observer(alias string, connection Driver) Observer {
+	if connection != nil && connection.HasObserver() {
+		return connection.Observer()
+	}
+
+	for name, configuration := range selector.configurations {
+		if alias == strings.ToLower(name) && configuration != nil {
+			return configuration.Observer
+		}
+	}
+
+	return nil
+}
+
 // Close closes all drivers connections.
+// The health checker is stopped if it's running.
 func (selector *Selector) Close() error {
+	selector.StopHealthCheck()
+
 	selector.mutex.Lock()
 	defer selector.mutex.Unlock()
 
@@ -141,6 +312,7 @@ func (selector *Selector) Close() error {
 	}
 
 	selector.connections = map[string]Driver{}
+	selector.unhealthy = map[string]error{}
 
 	if len(failures) > 0 {
 		return failures[0]
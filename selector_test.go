@@ -0,0 +1,152 @@
+package makroud_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/makroud"
+)
+
+type flakyDriver struct {
+	makroud.Driver
+	mutex    sync.Mutex
+	failure  error
+	observer makroud.Observer
+}
+
+func (d *flakyDriver) Fail(err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.failure = err
+}
+
+func (d *flakyDriver) Ping() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.failure != nil {
+		return d.failure
+	}
+	return d.Driver.Ping()
+}
+
+func (d *flakyDriver) HasObserver() bool {
+	return d.observer != nil
+}
+
+func (d *flakyDriver) Observer() makroud.Observer {
+	return d.observer
+}
+
+type healthEvent struct {
+	alias  string
+	action string
+	err    error
+}
+
+type healthObserver struct {
+	mutex  sync.Mutex
+	events []healthEvent
+}
+
+func (o *healthObserver) OnClose(err error, flags map[string]string) {}
+
+func (o *healthObserver) OnRollback(err error, flags map[string]string) {}
+
+func (o *healthObserver) OnHealthCheck(err error, flags map[string]string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.events = append(o.events, healthEvent{
+		alias:  flags["alias"],
+		action: flags["action"],
+		err:    err,
+	})
+}
+
+func (o *healthObserver) Events() []healthEvent {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return append([]healthEvent{}, o.events...)
+}
+
+func TestSelector_HealthCheck(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		is := require.New(t)
+
+		replica, err := makroud.New(Options()...)
+		is.NoError(err)
+
+		observer := &healthObserver{}
+		slave := &flakyDriver{Driver: replica, observer: observer}
+
+		selector, err := makroud.NewSelectorWithDrivers(map[string]makroud.Driver{
+			makroud.MasterSelector: driver,
+			makroud.SlaveSelector:  slave,
+		})
+		is.NoError(err)
+
+		selector.CheckHealth()
+		is.True(selector.IsHealthy(makroud.SlaveSelector))
+		is.True(selector.IsHealthy(makroud.MasterSelector))
+		is.Empty(observer.Events())
+
+		failure := errors.New("connection refused")
+		slave.Fail(failure)
+
+		selector.CheckHealth()
+		is.False(selector.IsHealthy(makroud.SlaveSelector))
+		is.True(selector.IsHealthy(makroud.MasterSelector))
+
+		events := observer.Events()
+		is.Len(events, 1)
+		is.Equal(makroud.SlaveSelector, events[0].alias)
+		is.Equal("eject", events[0].action)
+		is.Equal(failure, errors.Cause(events[0].err))
+
+		used := []makroud.Driver{}
+		err = selector.RetryMaster(func(driver makroud.Driver) error {
+			used = append(used, driver)
+			return nil
+		})
+		is.NoError(err)
+		is.Equal([]makroud.Driver{driver}, used)
+
+		used = []makroud.Driver{}
+		err = selector.RetryAliases(func(driver makroud.Driver) error {
+			used = append(used, driver)
+			return nil
+		}, makroud.SlaveSelector)
+		is.NoError(err)
+		is.Equal([]makroud.Driver{slave}, used)
+
+		router, err := makroud.NewRouter(selector)
+		is.NoError(err)
+		is.Equal(driver, router.Reader(context.Background()))
+
+		slave.Fail(nil)
+
+		err = selector.StartHealthCheck(10 * time.Millisecond)
+		is.NoError(err)
+
+		is.Eventually(func() bool {
+			return selector.IsHealthy(makroud.SlaveSelector)
+		}, time.Second, 10*time.Millisecond)
+
+		selector.StopHealthCheck()
+
+		events = observer.Events()
+		is.Len(events, 2)
+		is.Equal(makroud.SlaveSelector, events[1].alias)
+		is.Equal("reinstate", events[1].action)
+		is.NoError(events[1].err)
+
+		err = selector.StartHealthCheck(0)
+		is.Error(err)
+
+		is.NoError(replica.Close())
+	})
+}
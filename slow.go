@@ -0,0 +1,85 @@
+package makroud
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// fingerprintLiterals matches string and numeric literals of a query.
+	fingerprintLiterals = regexp.MustCompile(`'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+	// fingerprintSpaces matches consecutive whitespaces of a query.
+	fingerprintSpaces = regexp.MustCompile(`\s+`)
+)
+
+// GetQueryFingerprint returns a fingerprint of given query, which ignores its literals values and its whitespaces.
+func GetQueryFingerprint(query string) string {
+	query = fingerprintLiterals.ReplaceAllString(query, "?")
+	query = fingerprintSpaces.ReplaceAllString(strings.TrimSpace(query), " ")
+
+	hash := sha1.Sum([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
+
+// getSlowQueryObserver returns the observer of given client if it should be notified of slow queries.
+func getSlowQueryObserver(client *Client) (SlowQueryObserver, bool) {
+	if client.slw <= 0 {
+		return nil, false
+	}
+	observer, ok := client.obs.(SlowQueryObserver)
+	return observer, ok
+}
+
+// observeSlowQuery reports given query to the client observer if its duration exceeds the client threshold,
+// even if the query has failed.
+// If required, the query plan is also given, once per query fingerprint: it's explained by the client which has
+// executed the query, so it's the same connection, or the same transaction.
+func observeSlowQuery(ctx context.Context, client *Client, query string, args []interface{},
+	duration time.Duration, err error) {
+
+	observer, ok := getSlowQueryObserver(client)
+	if !ok || duration < client.slw {
+		return
+	}
+
+	fingerprint := GetQueryFingerprint(query)
+	flags := map[string]string{
+		"action":      "slow_query",
+		"query":       query,
+		"threshold":   client.slw.String(),
+		"fingerprint": fingerprint,
+	}
+
+	if err != nil {
+		flags["error"] = err.Error()
+	}
+
+	if client.exp != nil {
+		_, explained := client.exp.Load(fingerprint)
+		if !explained {
+			plan, err := explainQuery(ctx, client, query, args)
+			if err == nil {
+				client.exp.Store(fingerprint, true)
+				flags["plan"] = plan
+			}
+		}
+	}
+
+	observer.OnSlowQuery(duration, flags)
+}
+
+// explainQuery returns the plan of given query, in JSON.
+// It's executed on the client node, so it's neither logged nor reported as a slow query itself.
+func explainQuery(ctx context.Context, client *Client, query string, args []interface{}) (string, error) {
+	plan := ""
+	err := client.node.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&plan)
+	if err != nil {
+		return "", err
+	}
+
+	return plan, nil
+}
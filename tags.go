@@ -35,10 +35,16 @@ var TagsKeyMapper = map[string]string{
 // Tag modifiers on Model.
 const (
 	TagKeyIgnored       = "-"
+	TagKeyArray         = "array"
+	TagKeyCodec         = "codec"
+	TagKeyDB            = "db"
 	TagKeyDefault       = "default"
+	TagKeyEmbed         = "embed"
+	TagKeyPrefix        = "prefix"
 	TagKeyColumn        = "column"
 	TagKeyColumnShort   = "col"
 	TagKeyForeignKey    = "fk"
+	TagKeyJSON          = "json"
 	TagKeyPrimaryKey    = "pk"
 	TagKeyRelation      = "relation"
 	TagKeyRelationShort = "rel"
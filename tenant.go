@@ -0,0 +1,83 @@
+package makroud
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/ulule/loukoum/v3"
+	"github.com/ulule/loukoum/v3/builder"
+	"github.com/ulule/loukoum/v3/stmt"
+)
+
+// tenantKey is the context key used to store a tenant identifier.
+type tenantKey struct{}
+
+// WithTenant returns a copy of given context with the given tenant identifier.
+// This identifier will be used on every schema having the tenant column configured on the driver.
+func WithTenant(ctx context.Context, tenant interface{}) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// GetTenant returns the tenant identifier from given context, if any.
+func GetTenant(ctx context.Context) (interface{}, bool) {
+	tenant := ctx.Value(tenantKey{})
+	if tenant == nil {
+		return nil, false
+	}
+	return tenant, true
+}
+
+// getTenantField returns the tenant field of given schema.
+// If the driver has no tenant column or if the schema doesn't have this column, it returns false.
+func getTenantField(driver Driver, schema *Schema) (Field, bool) {
+	client := getClient(driver)
+	if client == nil || client.tenantColumn() == "" {
+		return Field{}, false
+	}
+
+	field, ok := schema.fields[client.tenantColumn()]
+	return field, ok
+}
+
+// getTenantValue returns the tenant identifier required by given schema.
+// If the schema doesn't require a tenant, it returns false.
+// If the schema requires a tenant but the context doesn't have one, it returns an error.
+func getTenantValue(ctx context.Context, driver Driver, schema *Schema) (Field, interface{}, bool, error) {
+	field, ok := getTenantField(driver, schema)
+	if !ok {
+		return Field{}, nil, false, nil
+	}
+
+	tenant, ok := GetTenant(ctx)
+	if !ok {
+		return Field{}, nil, false, errors.Wrapf(ErrTenantRequired, "cannot use %s without tenant", schema.ModelName())
+	}
+
+	return field, tenant, true, nil
+}
+
+// getTenantCondition returns the tenant predicate required by given schema.
+// If the schema doesn't require a tenant, it returns nil.
+func getTenantCondition(ctx context.Context, driver Driver, schema *Schema) (stmt.Expression, error) {
+	field, tenant, ok, err := getTenantValue(ctx, driver, schema)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return loukoum.Condition(field.ColumnPath()).Equal(tenant), nil
+}
+
+// applyTenantScope applies the tenant predicate required by given schema on query.
+func applyTenantScope(ctx context.Context, driver Driver, schema *Schema,
+	query builder.Select) (builder.Select, error) {
+
+	condition, err := getTenantCondition(ctx, driver, schema)
+	if err != nil {
+		return query, err
+	}
+	if condition == nil {
+		return query, nil
+	}
+
+	return query.Where(condition), nil
+}
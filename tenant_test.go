@@ -0,0 +1,113 @@
+package makroud_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/makroud"
+)
+
+func TestTenant(t *testing.T) {
+	Setup(t, makroud.WithTenantColumn("group_id"))(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		group1 := &Group{Name: "Spring"}
+		err := makroud.Save(ctx, driver, group1)
+		is.NoError(err)
+
+		group2 := &Group{Name: "Autumn"}
+		err = makroud.Save(ctx, driver, group2)
+		is.NoError(err)
+
+		ctx1 := makroud.WithTenant(ctx, group1.ID)
+		ctx2 := makroud.WithTenant(ctx, group2.ID)
+
+		owl1 := &Owl{Name: "Hedwig", FeatherColor: "white", FavoriteFood: "Mice"}
+		err = makroud.Save(ctx1, driver, owl1)
+		is.NoError(err)
+		is.True(owl1.GroupID.Valid)
+		is.Equal(group1.ID, owl1.GroupID.Int64)
+
+		owl2 := &Owl{Name: "Errol", FeatherColor: "grey", FavoriteFood: "Insects"}
+		err = makroud.Save(ctx2, driver, owl2)
+		is.NoError(err)
+		is.True(owl2.GroupID.Valid)
+		is.Equal(group2.ID, owl2.GroupID.Int64)
+
+		err = makroud.Save(ctx, driver, &Owl{Name: "Pigwidgeon", FeatherColor: "grey", FavoriteFood: "Seeds"})
+		is.Error(err)
+		is.Equal(makroud.ErrTenantRequired, errors.Cause(err))
+
+		owls := []Owl{}
+		err = makroud.Select(ctx1, driver, &owls)
+		is.NoError(err)
+		is.Len(owls, 1)
+		is.Equal(owl1.ID, owls[0].ID)
+
+		err = makroud.Select(ctx, driver, &owls)
+		is.Error(err)
+		is.Equal(makroud.ErrTenantRequired, errors.Cause(err))
+
+		owl1.Name = "Hedwige"
+		err = makroud.Save(ctx2, driver, owl1)
+		is.Error(err)
+		is.True(makroud.IsErrNoRows(err))
+
+		err = makroud.Delete(ctx2, driver, owl1)
+		is.NoError(err)
+
+		owl := &Owl{}
+		err = makroud.Select(ctx1, driver, owl)
+		is.NoError(err)
+		is.Equal(owl1.ID, owl.ID)
+		is.Equal("Hedwig", owl.Name)
+
+		err = makroud.Delete(ctx, driver, owl1)
+		is.Error(err)
+		is.Equal(makroud.ErrTenantRequired, errors.Cause(err))
+
+		bag := &Bag{Color: "red", OwlID: owl2.ID}
+		err = makroud.Save(ctx, driver, bag)
+		is.NoError(err)
+
+		err = makroud.Preload(ctx, driver, bag, makroud.WithPreloadField("Owl"))
+		is.Error(err)
+		is.Equal(makroud.ErrTenantRequired, errors.Cause(err))
+
+		err = makroud.Preload(ctx2, driver, bag, makroud.WithPreloadField("Owl"))
+		is.NoError(err)
+		is.Equal(owl2.ID, bag.Owl.ID)
+
+		roost := &Roost{Name: "Clock Tower"}
+		err = makroud.Save(ctx1, driver, roost)
+		is.NoError(err)
+
+		err = makroud.Archive(ctx2, driver, roost)
+		is.NoError(err)
+
+		result := &Roost{}
+		err = makroud.Select(ctx1, driver, result)
+		is.NoError(err)
+		is.Equal(roost.ID, result.ID)
+		is.False(result.DeletedAt.Valid)
+
+		err = makroud.Archive(ctx, driver, roost)
+		is.Error(err)
+		is.Equal(makroud.ErrTenantRequired, errors.Cause(err))
+
+		err = makroud.Archive(ctx1, driver, roost)
+		is.NoError(err)
+
+		err = makroud.Select(ctx1, driver, result)
+		is.Error(err)
+		is.True(makroud.IsErrNoRows(err))
+
+		err = makroud.Select(ctx1, driver, result, makroud.Unscoped())
+		is.NoError(err)
+		is.True(result.DeletedAt.Valid)
+	})
+}
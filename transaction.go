@@ -3,6 +3,10 @@ package makroud
 import (
 	"context"
 	"database/sql"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -42,13 +46,250 @@ func Transaction(ctx context.Context, driver Driver, opts *TxOptions,
 			driver.Observer().OnRollback(thr, nil)
 		}
 
+		observeTransactionMetrics(tx, "rollback")
 		return err
 	}
 
 	err = tx.Commit()
 	if err != nil {
+		observeTransactionMetrics(tx, "commit_error")
 		return err
 	}
 
+	observeTransactionMetrics(tx, "commit")
 	return nil
 }
+
+// inTransaction returns if given driver is a transaction.
+func inTransaction(driver Driver) bool {
+	client := getClient(driver)
+	return client != nil && client.node.Tx() != nil
+}
+
+// OnCommit registers a callback executed once the transaction of given driver is committed.
+// If the driver is a nested transaction, the callback is executed only once the outermost transaction is
+// committed, and it's discarded if the nested transaction is rolled back to its savepoint.
+// Without savepoint, a nested rollback doesn't undo anything: the callback is kept until the outermost
+// transaction is finished.
+// If the driver isn't a transaction, the callback is executed immediately.
+func OnCommit(driver Driver, callback func()) {
+	client := getClient(driver)
+	if client == nil || client.cbs == nil {
+		callback()
+		return
+	}
+
+	client.cbs.register(callback, nil)
+}
+
+// OnRollback registers a callback executed once the transaction of given driver is rolled back.
+// If the driver is a nested transaction, the callback is executed only once the outermost transaction is
+// finished, either if the nested transaction is rolled back to its savepoint or the outermost transaction
+// is rolled back.
+// Without savepoint, a nested rollback doesn't undo anything: the callback is executed only if the outermost
+// transaction is rolled back.
+// If the driver isn't a transaction, the callback is discarded.
+func OnRollback(driver Driver, callback func()) {
+	client := getClient(driver)
+	if client == nil || client.cbs == nil {
+		return
+	}
+
+	client.cbs.register(nil, callback)
+}
+
+// txCallbacks contains callbacks registered on a transaction, waiting for the outermost one to finish.
+type txCallbacks struct {
+	mutex     sync.Mutex
+	parent    *txCallbacks
+	done      bool
+	commits   []func()
+	rollbacks []func()
+	// undone contains rollback callbacks of nested transactions already rolled back.
+	undone []func()
+}
+
+// newTxCallbacks creates a new txCallbacks instance for a transaction nested in given parent, if any.
+func newTxCallbacks(parent *txCallbacks) *txCallbacks {
+	return &txCallbacks{
+		parent: parent,
+	}
+}
+
+// register adds given callbacks, if they are defined.
+func (cbs *txCallbacks) register(commit func(), rollback func()) {
+	cbs.mutex.Lock()
+	defer cbs.mutex.Unlock()
+
+	if cbs.done {
+		return
+	}
+	if commit != nil {
+		cbs.commits = append(cbs.commits, commit)
+	}
+	if rollback != nil {
+		cbs.rollbacks = append(cbs.rollbacks, rollback)
+	}
+}
+
+// finish marks the transaction as finished and returns its pending callbacks.
+func (cbs *txCallbacks) finish() ([]func(), []func(), []func(), bool) {
+	cbs.mutex.Lock()
+	defer cbs.mutex.Unlock()
+
+	if cbs.done {
+		return nil, nil, nil, false
+	}
+
+	commits, rollbacks, undone := cbs.commits, cbs.rollbacks, cbs.undone
+	cbs.done = true
+	cbs.commits = nil
+	cbs.rollbacks = nil
+	cbs.undone = nil
+
+	return commits, rollbacks, undone, true
+}
+
+// commit executes commit callbacks if it's the outermost transaction, or hands them to its parent otherwise.
+func (cbs *txCallbacks) commit() {
+	commits, rollbacks, undone, ok := cbs.finish()
+	if !ok {
+		return
+	}
+
+	if cbs.parent != nil {
+		cbs.parent.mutex.Lock()
+		defer cbs.parent.mutex.Unlock()
+		cbs.parent.commits = append(cbs.parent.commits, commits...)
+		cbs.parent.rollbacks = append(cbs.parent.rollbacks, rollbacks...)
+		cbs.parent.undone = append(cbs.parent.undone, undone...)
+		return
+	}
+
+	executeCallbacks(commits)
+	executeCallbacks(undone)
+}
+
+// rollback executes rollback callbacks if it's the outermost transaction, or hands them to its parent otherwise.
+func (cbs *txCallbacks) rollback() {
+	_, rollbacks, undone, ok := cbs.finish()
+	if !ok {
+		return
+	}
+
+	if cbs.parent != nil {
+		cbs.parent.mutex.Lock()
+		defer cbs.parent.mutex.Unlock()
+		cbs.parent.undone = append(cbs.parent.undone, undone...)
+		cbs.parent.undone = append(cbs.parent.undone, rollbacks...)
+		return
+	}
+
+	executeCallbacks(undone)
+	executeCallbacks(rollbacks)
+}
+
+// executeCallbacks executes given callbacks.
+func executeCallbacks(callbacks []func()) {
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// RetryOptions configure how TransactionWithRetry reruns a transaction.
+type RetryOptions struct {
+	// MaxRetries defines how many times a transaction is rerun after its first attempt.
+	MaxRetries int
+	// MinBackoff defines the delay before the first retry.
+	MinBackoff time.Duration
+	// MaxBackoff defines the maximum delay between two retries.
+	MaxBackoff time.Duration
+}
+
+// NewRetryOptions creates a new RetryOptions instance with default options.
+func NewRetryOptions() *RetryOptions {
+	return &RetryOptions{
+		MaxRetries: 3,
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 1 * time.Second,
+	}
+}
+
+// TransactionWithRetry will creates a transaction, like Transaction.
+// However, if the transaction fails with a serialization failure or a deadlock, it's rerun
+// after a jittered backoff, until it succeeds or reaches the retries limit.
+// Each retry is reported to the driver observer, if it implements RetryObserver.
+//
+// Only an outermost transaction is rerun: if the driver is already a transaction, it's aborted by such failure,
+// so the error is returned immediately and the retry is left to the caller owning the outermost transaction.
+//
+// If no RetryOptions is provided, the default options will be used.
+func TransactionWithRetry(ctx context.Context, driver Driver, opts *TxOptions, retry *RetryOptions,
+	handler func(driver Driver) error) error {
+
+	if retry == nil {
+		retry = NewRetryOptions()
+	}
+
+	if inTransaction(driver) {
+		return Transaction(ctx, driver, opts, handler)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := Transaction(ctx, driver, opts, handler)
+		if err == nil {
+			return nil
+		}
+
+		code, ok := getRetryableCode(err)
+		if !ok || attempt > retry.MaxRetries {
+			return err
+		}
+
+		observer, ok := driver.Observer().(RetryObserver)
+		if ok {
+			observer.OnRetry(errors.Wrap(err, "makroud: retrying transaction"), map[string]string{
+				"action":  "retry",
+				"attempt": strconv.Itoa(attempt),
+				"code":    code,
+			})
+		}
+
+		timer := time.NewTimer(getRetryBackoff(retry, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// getRetryableCode returns the SQLSTATE code of given error if the transaction could succeed on a rerun.
+func getRetryableCode(err error) (string, bool) {
+	switch {
+	case IsSerializationFailure(err):
+		return SerializationFailureCode, true
+	case IsDeadlock(err):
+		return DeadlockDetectedCode, true
+	default:
+		return "", false
+	}
+}
+
+// getRetryBackoff returns a jittered delay for given attempt, using an exponential backoff.
+func getRetryBackoff(retry *RetryOptions, attempt int) time.Duration {
+	backoff := retry.MinBackoff
+	for i := 1; i < attempt && backoff < retry.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > retry.MaxBackoff {
+		backoff = retry.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := int64(backoff / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/ulule/loukoum/v3"
 
 	"github.com/ulule/makroud"
+	"github.com/ulule/makroud/makroudtest"
 )
 
 func TestTransaction_Commit(t *testing.T) {
@@ -216,3 +219,244 @@ func TestTransaction_IsolationLevel(t *testing.T) {
 		}
 	})
 }
+
+func TestTransaction_Retry(t *testing.T) {
+	observer := &testObserver{}
+	Setup(t, makroud.WithObserver(observer))(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		retry := &makroud.RetryOptions{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		}
+
+		opts := &makroud.TxOptions{
+			Isolation: makroud.LevelSerializable,
+		}
+
+		cat := &Cat{Name: "Ruffles"}
+		attempts := 0
+
+		err := makroud.TransactionWithRetry(ctx, driver, opts, retry, func(tx makroud.Driver) error {
+			attempts++
+			if attempts < 3 {
+				return errors.WithStack(&pq.Error{Code: "40001"})
+			}
+			return makroud.Save(ctx, tx, cat)
+		})
+		is.NoError(err)
+		is.Equal(3, attempts)
+		is.NotZero(cat.ID)
+
+		events := observer.Events("OnRetry")
+		is.Len(events, 2)
+		is.Equal("1", events[0].flags["attempt"])
+		is.Equal("40001", events[0].flags["code"])
+		is.Equal("2", events[1].flags["attempt"])
+
+		attempts = 0
+		err = makroud.TransactionWithRetry(ctx, driver, opts, retry, func(tx makroud.Driver) error {
+			attempts++
+			return &pq.Error{Code: "40P01"}
+		})
+		is.Error(err)
+		is.Equal(3, attempts)
+		is.Len(observer.Events("OnRetry"), 4)
+
+		timeout := errors.New("tcp: read timeout on 10.0.3.11:7000")
+		attempts = 0
+		err = makroud.TransactionWithRetry(ctx, driver, nil, nil, func(tx makroud.Driver) error {
+			attempts++
+			return timeout
+		})
+		is.Equal(timeout, err)
+		is.Equal(1, attempts)
+
+		attempts = 0
+		err = makroud.Transaction(ctx, driver, opts, func(tx makroud.Driver) error {
+			return makroud.TransactionWithRetry(ctx, tx, nil, retry, func(tx makroud.Driver) error {
+				attempts++
+				return &pq.Error{Code: "40001"}
+			})
+		})
+		is.Error(err)
+		is.True(makroud.IsSerializationFailure(err))
+		is.Equal(1, attempts)
+		is.Len(observer.Events("OnRetry"), 4)
+	})
+}
+
+func TestTransaction_Callbacks(t *testing.T) {
+	Setup(t, makroud.EnableSavepoint())(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		events := []string{}
+		record := func(event string) func() {
+			return func() {
+				events = append(events, event)
+			}
+		}
+
+		makroud.OnCommit(driver, record("immediate"))
+		makroud.OnRollback(driver, record("never"))
+		is.Equal([]string{"immediate"}, events)
+
+		events = []string{}
+		err := makroud.Transaction(ctx, driver, nil, func(tx1 makroud.Driver) error {
+			makroud.OnCommit(tx1, record("commit-1"))
+			makroud.OnRollback(tx1, record("rollback-1"))
+
+			err := makroud.Transaction(ctx, tx1, nil, func(tx2 makroud.Driver) error {
+				makroud.OnCommit(tx2, record("commit-2"))
+				makroud.OnRollback(tx2, record("rollback-2"))
+				return nil
+			})
+			is.NoError(err)
+
+			timeout := errors.New("tcp: read timeout on 10.0.3.11:7000")
+			err = makroud.Transaction(ctx, tx1, nil, func(tx3 makroud.Driver) error {
+				makroud.OnCommit(tx3, record("commit-3"))
+				makroud.OnRollback(tx3, record("rollback-3"))
+				return timeout
+			})
+			is.Equal(timeout, err)
+
+			is.Empty(events)
+			return nil
+		})
+		is.NoError(err)
+		is.Equal([]string{"commit-1", "commit-2", "rollback-3"}, events)
+
+		events = []string{}
+		err = makroud.Transaction(ctx, driver, nil, func(tx1 makroud.Driver) error {
+			makroud.OnCommit(tx1, record("commit-1"))
+			makroud.OnRollback(tx1, record("rollback-1"))
+
+			err := makroud.Transaction(ctx, tx1, nil, func(tx2 makroud.Driver) error {
+				makroud.OnCommit(tx2, record("commit-2"))
+				makroud.OnRollback(tx2, record("rollback-2"))
+				return nil
+			})
+			is.NoError(err)
+
+			is.Empty(events)
+			return sql.ErrConnDone
+		})
+		is.Equal(sql.ErrConnDone, err)
+		is.Equal([]string{"rollback-1", "rollback-2"}, events)
+	})
+}
+
+func TestTransaction_CallbacksWithoutSavepoint(t *testing.T) {
+	Setup(t)(func(driver makroud.Driver) {
+		ctx := context.Background()
+		is := require.New(t)
+
+		events := []string{}
+		record := func(event string) func() {
+			return func() {
+				events = append(events, event)
+			}
+		}
+
+		timeout := errors.New("tcp: read timeout on 10.0.3.11:7000")
+
+		err := makroud.Transaction(ctx, driver, nil, func(tx1 makroud.Driver) error {
+			makroud.OnCommit(tx1, record("commit-1"))
+			makroud.OnRollback(tx1, record("rollback-1"))
+
+			err := makroud.Transaction(ctx, tx1, nil, func(tx2 makroud.Driver) error {
+				makroud.OnCommit(tx2, record("commit-2"))
+				makroud.OnRollback(tx2, record("rollback-2"))
+				return makroud.Save(ctx, tx2, &Cat{Name: "Spot"})
+			})
+			is.NoError(err)
+
+			err = makroud.Transaction(ctx, tx1, nil, func(tx3 makroud.Driver) error {
+				makroud.OnCommit(tx3, record("commit-3"))
+				makroud.OnRollback(tx3, record("rollback-3"))
+				err := makroud.Save(ctx, tx3, &Cat{Name: "Tiger"})
+				is.NoError(err)
+				return timeout
+			})
+			is.Equal(timeout, err)
+
+			is.Empty(events)
+			return nil
+		})
+		is.NoError(err)
+		is.Equal([]string{"commit-1", "commit-2", "commit-3"}, events)
+
+		count, err := makroud.Count(ctx, driver, loukoum.Select(loukoum.Count("id")).From("ztp_cat").
+			Where(loukoum.Condition("name").In("Spot", "Tiger")))
+		is.NoError(err)
+		is.Equal(int64(2), count)
+
+		events = []string{}
+		err = makroud.Transaction(ctx, driver, nil, func(tx1 makroud.Driver) error {
+			makroud.OnCommit(tx1, record("commit-1"))
+			makroud.OnRollback(tx1, record("rollback-1"))
+
+			err := makroud.Transaction(ctx, tx1, nil, func(tx2 makroud.Driver) error {
+				makroud.OnCommit(tx2, record("commit-2"))
+				makroud.OnRollback(tx2, record("rollback-2"))
+				return timeout
+			})
+			is.Equal(timeout, err)
+
+			is.Empty(events)
+			return sql.ErrConnDone
+		})
+		is.Equal(sql.ErrConnDone, err)
+		is.Equal([]string{"rollback-1", "rollback-2"}, events)
+	})
+}
+
+type embeddedClient struct {
+	*makroud.Client
+}
+
+func TestTransaction_CallbacksOnEmbeddedClient(t *testing.T) {
+	ctx := context.Background()
+	is := require.New(t)
+
+	driver, err := makroudtest.New()
+	is.NoError(err)
+
+	events := []string{}
+	record := func(event string) func() {
+		return func() {
+			events = append(events, event)
+		}
+	}
+
+	err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+		client, ok := tx.(*makroud.Client)
+		is.True(ok)
+
+		makroud.OnCommit(embeddedClient{client}, record("commit"))
+		makroud.OnRollback(embeddedClient{client}, record("rollback"))
+
+		is.Empty(events)
+		return nil
+	})
+	is.NoError(err)
+	is.Equal([]string{"commit"}, events)
+
+	events = []string{}
+	err = makroud.Transaction(ctx, driver, nil, func(tx makroud.Driver) error {
+		client, ok := tx.(*makroud.Client)
+		is.True(ok)
+
+		makroud.OnCommit(embeddedClient{client}, record("commit"))
+		makroud.OnRollback(embeddedClient{client}, record("rollback"))
+
+		is.Empty(events)
+		return sql.ErrConnDone
+	})
+	is.Equal(sql.ErrConnDone, err)
+	is.Equal([]string{"rollback"}, events)
+}